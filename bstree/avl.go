@@ -0,0 +1,246 @@
+package bstree
+
+import (
+	"github.com/esimov/gogu"
+	"golang.org/x/exp/constraints"
+)
+
+// AvlTree is a self-balancing variant of the BST, where the heights of the two child subtrees
+// of any node differ by at most one. This keeps the tree height under 1.44*log2(n+2),
+// guaranteeing O(log n) lookups, insertions and deletions even when the keys are inserted in sorted order.
+// It has the same API as the BsTree, the read-only methods being forwarded to the underlying tree.
+type AvlTree[K constraints.Ordered, V any] struct {
+	tree *BsTree[K, V]
+}
+
+// NewAVL initializes a new AVL tree together with a comparison operator.
+// Depending on the comparator it sorts the tree in ascending or descending order.
+func NewAVL[K constraints.Ordered, V any](comp gogu.CompFn[K]) *AvlTree[K, V] {
+	return &AvlTree[K, V]{New[K, V](comp)}
+}
+
+// Height returns the height of the tree. Since the nodes of the AVL tree keep track of
+// the height of their subtree, it runs in constant time.
+func (t *AvlTree[K, V]) Height() int {
+	t.tree.mu.RLock()
+	defer t.tree.mu.RUnlock()
+
+	return t.tree.root.heightOf()
+}
+
+// Size returns the size of the tree.
+func (t *AvlTree[K, V]) Size() int {
+	return t.tree.Size()
+}
+
+// Get retrieves the node item and an error in case the requested node does not exists.
+func (t *AvlTree[K, V]) Get(key K) (Item[K, V], error) {
+	return t.tree.Get(key)
+}
+
+// Traverse iterates over the tree structure and invokes the callback function provided as a parameter.
+func (t *AvlTree[K, V]) Traverse(fn func(Item[K, V])) {
+	t.tree.Traverse(fn)
+}
+
+// Iterator returns a new iterator, which is not positioned on any item yet.
+func (t *AvlTree[K, V]) Iterator() *Iterator[K, V] {
+	return t.tree.Iterator()
+}
+
+// Ascend iterates over the tree items in the order defined by the comparator, until the callback
+// function returns false. The tree lock is not held while the callback is running, see BsTree.Ascend.
+func (t *AvlTree[K, V]) Ascend(fn func(Item[K, V]) bool) {
+	t.tree.Ascend(fn)
+}
+
+// Descend iterates over the tree items in reverse order, until the callback function returns false.
+// Like in case of Ascend, the tree lock is not held while the callback is running.
+func (t *AvlTree[K, V]) Descend(fn func(Item[K, V]) bool) {
+	t.tree.Descend(fn)
+}
+
+// Min returns the item with the smallest key, considering the order defined by the comparator.
+// It returns an error if the tree is empty.
+func (t *AvlTree[K, V]) Min() (Item[K, V], error) {
+	return t.tree.Min()
+}
+
+// Max returns the item with the largest key, considering the order defined by the comparator.
+// It returns an error if the tree is empty.
+func (t *AvlTree[K, V]) Max() (Item[K, V], error) {
+	return t.tree.Max()
+}
+
+// Floor returns the item with the largest key less than or equal to the provided key.
+// It returns an error if there is no such item.
+func (t *AvlTree[K, V]) Floor(key K) (Item[K, V], error) {
+	return t.tree.Floor(key)
+}
+
+// Ceiling returns the item with the smallest key greater than or equal to the provided key.
+// It returns an error if there is no such item.
+func (t *AvlTree[K, V]) Ceiling(key K) (Item[K, V], error) {
+	return t.tree.Ceiling(key)
+}
+
+// Predecessor returns the item with the largest key strictly less than the provided key.
+// The key itself does not need to exist in the tree. It returns an error if there is no such item.
+func (t *AvlTree[K, V]) Predecessor(key K) (Item[K, V], error) {
+	return t.tree.Predecessor(key)
+}
+
+// Successor returns the item with the smallest key strictly greater than the provided key.
+// The key itself does not need to exist in the tree. It returns an error if there is no such item.
+func (t *AvlTree[K, V]) Successor(key K) (Item[K, V], error) {
+	return t.tree.Successor(key)
+}
+
+// Range returns the items having the keys between lo and hi inclusively, in the order defined by the comparator.
+func (t *AvlTree[K, V]) Range(lo, hi K) []Item[K, V] {
+	return t.tree.Range(lo, hi)
+}
+
+// Rank returns the number of keys strictly less than the provided key.
+// The key itself does not need to exist in the tree. It runs in O(height) time.
+func (t *AvlTree[K, V]) Rank(key K) int {
+	return t.tree.Rank(key)
+}
+
+// Select returns the item with the provided rank, which means the i-th smallest item starting from zero.
+// It returns an error if the rank is out of range. It runs in O(height) time.
+func (t *AvlTree[K, V]) Select(i int) (Item[K, V], error) {
+	return t.tree.Select(i)
+}
+
+// Upsert insert a new node or update an existing node in case the key is found in the tree list.
+// The tree is rebalanced on the way back from the inserted node to the root.
+func (t *AvlTree[K, V]) Upsert(key K, val V) {
+	t.tree.mu.Lock()
+	defer t.tree.mu.Unlock()
+
+	t.tree.root = t.upsert(t.tree.root, key, val)
+}
+
+func (t *AvlTree[K, V]) upsert(n *Node[K, V], key K, val V) *Node[K, V] {
+	if n == nil {
+		t.tree.size++
+		return NewNode(key, val)
+	}
+
+	switch gogu.Compare(key, n.Key, t.tree.comp) {
+	case 1:
+		n.Left = t.upsert(n.Left, key, val)
+	case -1:
+		n.Right = t.upsert(n.Right, key, val)
+	default:
+		n.Val = val
+		return n
+	}
+
+	return n.rebalance()
+}
+
+// Delete removes a node defined by its key from the tree structure.
+// The tree is rebalanced on the way back from the removed node to the root.
+func (t *AvlTree[K, V]) Delete(key K) error {
+	var err error
+	t.tree.mu.Lock()
+	defer t.tree.mu.Unlock()
+
+	t.tree.root, err = t.delete(t.tree.root, key)
+	if err == nil {
+		t.tree.size--
+	}
+
+	return err
+}
+
+func (t *AvlTree[K, V]) delete(n *Node[K, V], key K) (*Node[K, V], error) {
+	var err error
+	if n == nil {
+		return nil, ErrorNotFound
+	}
+
+	switch gogu.Compare(key, n.Key, t.tree.comp) {
+	case 1:
+		n.Left, err = t.delete(n.Left, key)
+	case -1:
+		n.Right, err = t.delete(n.Right, key)
+	default:
+		if n.Left == nil {
+			return n.Right, nil
+		}
+		if n.Right == nil {
+			return n.Left, nil
+		}
+		// Replace the node with its inorder successor, then delete the successor.
+		min := n.Right.min()
+		n.Item = min.Item
+		n.Right, err = t.delete(n.Right, min.Key)
+	}
+
+	return n.rebalance(), err
+}
+
+// heightOf returns the height of the subtree rooted at n.
+func (n *Node[K, V]) heightOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// update recomputes the height and the size of the node from its children.
+func (n *Node[K, V]) update() {
+	n.height = 1 + gogu.Max(n.Left.heightOf(), n.Right.heightOf())
+	n.resize()
+}
+
+// balance returns the difference between the heights of the left and right subtrees.
+func (n *Node[K, V]) balance() int {
+	return n.Left.heightOf() - n.Right.heightOf()
+}
+
+// rebalance restores the AVL property of the node, whose subtrees are already balanced,
+// and returns the new root of the subtree.
+func (n *Node[K, V]) rebalance() *Node[K, V] {
+	n.update()
+
+	switch b := n.balance(); {
+	case b > 1:
+		if n.Left.balance() < 0 {
+			n.Left = n.Left.rotateLeft()
+		}
+		return n.rotateRight()
+	case b < -1:
+		if n.Right.balance() > 0 {
+			n.Right = n.Right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+
+	return n
+}
+
+// rotateLeft makes the right child the new root of the subtree.
+func (n *Node[K, V]) rotateLeft() *Node[K, V] {
+	r := n.Right
+	n.Right = r.Left
+	r.Left = n
+	n.update()
+	r.update()
+
+	return r
+}
+
+// rotateRight makes the left child the new root of the subtree.
+func (n *Node[K, V]) rotateRight() *Node[K, V] {
+	l := n.Left
+	n.Left = l.Right
+	l.Right = n
+	n.update()
+	l.update()
+
+	return l
+}
@@ -0,0 +1,118 @@
+package bstree
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// maxAvlHeight returns the upper bound of the AVL tree height having n nodes.
+func maxAvlHeight(n int) int {
+	return int(1.44 * math.Log2(float64(n+2)))
+}
+
+// checkAvl verifies the AVL property and the bookkeeping of every node of the subtree.
+func checkAvl(assert *assert.Assertions, n *Node[int, int]) {
+	if n == nil {
+		return
+	}
+	checkAvl(assert, n.Left)
+	checkAvl(assert, n.Right)
+
+	assert.LessOrEqual(n.balance(), 1)
+	assert.GreaterOrEqual(n.balance(), -1)
+	assert.Equal(n.depth(), n.height)
+	assert.Equal(1+n.Left.length()+n.Right.length(), n.size)
+}
+
+func TestAvlTree_SortedInsert(t *testing.T) {
+	assert := assert.New(t)
+
+	n := 10000
+	avl := NewAVL[int, int](func(a, b int) bool { return a < b })
+	bst := New[int, int](func(a, b int) bool { return a < b })
+	for i := 0; i < n; i++ {
+		avl.Upsert(i, i)
+		if i < 1000 {
+			bst.Upsert(i, i)
+		}
+	}
+	assert.Equal(n, avl.Size())
+	assert.LessOrEqual(avl.Height(), maxAvlHeight(n))
+	assert.Equal(avl.tree.Height(), avl.Height())
+	checkAvl(assert, avl.tree.root)
+
+	// The unbalanced tree degrades to a linked list.
+	assert.Equal(1000, bst.Height())
+
+	for i := 0; i < n; i += 2 {
+		assert.NoError(avl.Delete(i))
+	}
+	assert.Error(avl.Delete(0))
+	assert.Equal(n/2, avl.Size())
+	assert.LessOrEqual(avl.Height(), maxAvlHeight(n/2))
+	checkAvl(assert, avl.tree.root)
+
+	for i := 0; i < n; i++ {
+		item, err := avl.Get(i)
+		if i%2 == 0 {
+			assert.Error(err)
+		} else {
+			assert.NoError(err)
+			assert.Equal(i, item.Val)
+		}
+	}
+}
+
+func TestAvlTree_Random(t *testing.T) {
+	assert := assert.New(t)
+
+	avl := NewAVL[int, int](func(a, b int) bool { return a > b })
+	tmp := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		key := rand.Intn(1000)
+		if rand.Intn(3) == 0 {
+			_, ok := tmp[key]
+			assert.Equal(ok, avl.Delete(key) == nil)
+			delete(tmp, key)
+			continue
+		}
+		val := rand.Int()
+		avl.Upsert(key, val)
+		tmp[key] = val
+	}
+	assert.Equal(len(tmp), avl.Size())
+	assert.LessOrEqual(avl.Height(), maxAvlHeight(len(tmp)))
+	checkAvl(assert, avl.tree.root)
+
+	// The ordered operations are shared with the unbalanced tree.
+	prev := math.MaxInt
+	avl.Traverse(func(item Item[int, int]) {
+		assert.Less(item.Key, prev)
+		assert.Equal(tmp[item.Key], item.Val)
+		prev = item.Key
+	})
+	first, err := avl.Select(0)
+	assert.NoError(err)
+	min, _ := avl.Min()
+	assert.Equal(min.Key, first.Key)
+}
+
+func Example_avl() {
+	avl := NewAVL[int, string](func(a, b int) bool { return a < b })
+	for i := 1; i <= 7; i++ {
+		avl.Upsert(i, fmt.Sprint("v", i))
+	}
+	fmt.Println(avl.Size(), avl.Height())
+
+	avl.Delete(4)
+	item, _ := avl.Ceiling(4)
+	fmt.Println(item.Val)
+
+	// Output:
+	// 7 3
+	// v5
+}
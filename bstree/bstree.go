@@ -26,6 +26,10 @@ type Node[K constraints.Ordered, V any] struct {
 	Left  *Node[K, V]
 	Right *Node[K, V]
 	Item[K, V]
+	// size is the number of nodes in the subtree rooted at this node.
+	size int
+	// height is the height of the subtree rooted at this node. It's maintained only by the AVL tree.
+	height int
 }
 
 // NewNode creates a new node.
@@ -35,6 +39,8 @@ func NewNode[K constraints.Ordered, V any](key K, val V) *Node[K, V] {
 			Key: key,
 			Val: val,
 		},
+		size:   1,
+		height: 1,
 	}
 }
 
@@ -65,6 +71,22 @@ func (b *BsTree[K, V]) Size() int {
 	return b.size
 }
 
+// Height returns the height of the tree, which is the number of nodes on the longest path
+// from the root to a leaf. It runs in linear time.
+func (b *BsTree[K, V]) Height() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.root.depth()
+}
+
+func (n *Node[K, V]) depth() int {
+	if n == nil {
+		return 0
+	}
+	return 1 + gogu.Max(n.Left.depth(), n.Right.depth())
+}
+
 // Get retrieves the node item and an error in case the requested node does not exists.
 func (b *BsTree[K, V]) Get(key K) (Item[K, V], error) {
 	b.mu.RLock()
@@ -120,6 +142,7 @@ func (n *Node[K, V]) upsert(b *BsTree[K, V], key K, val V) {
 	} else {
 		n.Val = val
 	}
+	n.resize()
 }
 
 // min searches for the latest node on the left branch, but considering that BST
@@ -133,10 +156,13 @@ func (n *Node[K, V]) min() *Node[K, V] {
 // Delete removes a node defined by its key from the tree structure.
 func (b *BsTree[K, V]) Delete(key K) error {
 	var err error
-	b.mu.RLock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	b.root, err = b.root.delete(b, key)
-	b.size--
-	b.mu.RUnlock()
+	if err == nil {
+		b.size--
+	}
 
 	return err
 }
@@ -149,9 +175,11 @@ func (n *Node[K, V]) delete(b *BsTree[K, V], key K) (*Node[K, V], error) {
 
 	if gogu.Compare(key, n.Key, b.comp) == 1 {
 		n.Left, err = n.Left.delete(b, key)
+		n.resize()
 		return n, err
 	} else if gogu.Compare(key, n.Key, b.comp) == -1 {
 		n.Right, err = n.Right.delete(b, key)
+		n.resize()
 		return n, err
 	} else {
 		// case 1: node has no child
@@ -174,11 +202,25 @@ func (n *Node[K, V]) delete(b *BsTree[K, V], key K) (*Node[K, V], error) {
 		n.Val = min.Val
 		// Delete the inorder successor.
 		n.Right, err = n.Right.delete(b, min.Key)
+		n.resize()
 
 		return n, err
 	}
 }
 
+// length returns the number of nodes in the subtree rooted at n.
+func (n *Node[K, V]) length() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// resize updates the subtree size of the node after its children have been changed.
+func (n *Node[K, V]) resize() {
+	n.size = 1 + n.Left.length() + n.Right.length()
+}
+
 // Traverse iterates over the tree structure and invokes the callback function provided as a parameter.
 func (b *BsTree[K, V]) Traverse(fn func(Item[K, V])) {
 	ch := make(chan Item[K, V])
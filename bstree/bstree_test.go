@@ -110,13 +110,15 @@ func Example() {
 	fmt.Println(bst.Size())
 
 	tree := []string{}
+	keys := []int{}
 	bst.Traverse(func(item Item[int, string]) {
 		node, _ := bst.Get(item.Key)
 		tree = append(tree, node.Val)
+		keys = append(keys, item.Key)
 	})
 	fmt.Println(tree)
 
-	for key := range tree {
+	for _, key := range keys {
 		bst.Delete(key)
 	}
 
@@ -0,0 +1,193 @@
+package bstree
+
+import (
+	"github.com/esimov/gogu"
+	"golang.org/x/exp/constraints"
+)
+
+// Iterator is a bidirectional cursor over the tree items, in the order defined by the comparator.
+// It keeps the path from the root to the current node in an explicit stack, so it doesn't need
+// a goroutine and it doesn't hold the tree lock between the steps. This means that the tree
+// can be accessed while iterating, but the iterator has to be repositioned with First, Last or Seek
+// after the tree has been modified.
+type Iterator[K constraints.Ordered, V any] struct {
+	tree  *BsTree[K, V]
+	stack []*Node[K, V]
+	// item is a copy of the current item, taken while holding the tree lock,
+	// since the nodes are updated in place by Upsert and Delete.
+	item Item[K, V]
+}
+
+// Iterator returns a new iterator, which is not positioned on any item yet.
+func (b *BsTree[K, V]) Iterator() *Iterator[K, V] {
+	return &Iterator[K, V]{tree: b}
+}
+
+// Valid checks if the iterator is positioned on an item.
+func (it *Iterator[K, V]) Valid() bool {
+	return len(it.stack) > 0
+}
+
+// Item returns the item the iterator is positioned on, or the zero value if the iterator is not valid.
+func (it *Iterator[K, V]) Item() Item[K, V] {
+	return it.item
+}
+
+// position copies the item of the current node. It should be called while holding the tree lock.
+// It returns false if the iterator is not valid.
+func (it *Iterator[K, V]) position() bool {
+	if !it.Valid() {
+		var item Item[K, V]
+		it.item = item
+		return false
+	}
+	it.item = it.stack[len(it.stack)-1].Item
+
+	return true
+}
+
+// First positions the iterator on the first item of the tree.
+// It returns false if the tree is empty.
+func (it *Iterator[K, V]) First() bool {
+	it.tree.mu.RLock()
+	defer it.tree.mu.RUnlock()
+
+	it.stack = it.stack[:0]
+	it.pushLeft(it.tree.root)
+
+	return it.position()
+}
+
+// Last positions the iterator on the last item of the tree.
+// It returns false if the tree is empty.
+func (it *Iterator[K, V]) Last() bool {
+	it.tree.mu.RLock()
+	defer it.tree.mu.RUnlock()
+
+	it.stack = it.stack[:0]
+	it.pushRight(it.tree.root)
+
+	return it.position()
+}
+
+// Seek positions the iterator on the first item with the key greater than or equal to the provided key.
+// It returns false if there is no such item.
+func (it *Iterator[K, V]) Seek(key K) bool {
+	it.tree.mu.RLock()
+	defer it.tree.mu.RUnlock()
+
+	it.stack = it.stack[:0]
+	// found is the stack length at the deepest node which is greater than the key.
+	found := 0
+	for n := it.tree.root; n != nil; {
+		it.stack = append(it.stack, n)
+		switch gogu.Compare(key, n.Key, it.tree.comp) {
+		case 1:
+			found = len(it.stack)
+			n = n.Left
+		case -1:
+			n = n.Right
+		default:
+			return it.position()
+		}
+	}
+	it.stack = it.stack[:found]
+
+	return it.position()
+}
+
+// Next moves the iterator to the next item. It returns false if there are no more items,
+// in which case the iterator becomes invalid.
+func (it *Iterator[K, V]) Next() bool {
+	it.tree.mu.RLock()
+	defer it.tree.mu.RUnlock()
+
+	if !it.Valid() {
+		return it.position()
+	}
+
+	n := it.stack[len(it.stack)-1]
+	if n.Right != nil {
+		it.pushLeft(n.Right)
+		return it.position()
+	}
+	// Go up until arriving from a left child.
+	for {
+		it.stack = it.stack[:len(it.stack)-1]
+		if !it.Valid() {
+			return it.position()
+		}
+		parent := it.stack[len(it.stack)-1]
+		if parent.Left == n {
+			return it.position()
+		}
+		n = parent
+	}
+}
+
+// Prev moves the iterator to the previous item. It returns false if there are no more items,
+// in which case the iterator becomes invalid.
+func (it *Iterator[K, V]) Prev() bool {
+	it.tree.mu.RLock()
+	defer it.tree.mu.RUnlock()
+
+	if !it.Valid() {
+		return it.position()
+	}
+
+	n := it.stack[len(it.stack)-1]
+	if n.Left != nil {
+		it.pushRight(n.Left)
+		return it.position()
+	}
+	// Go up until arriving from a right child.
+	for {
+		it.stack = it.stack[:len(it.stack)-1]
+		if !it.Valid() {
+			return it.position()
+		}
+		parent := it.stack[len(it.stack)-1]
+		if parent.Right == n {
+			return it.position()
+		}
+		n = parent
+	}
+}
+
+// pushLeft pushes the node and its left descendants into the stack.
+func (it *Iterator[K, V]) pushLeft(n *Node[K, V]) {
+	for ; n != nil; n = n.Left {
+		it.stack = append(it.stack, n)
+	}
+}
+
+// pushRight pushes the node and its right descendants into the stack.
+func (it *Iterator[K, V]) pushRight(n *Node[K, V]) {
+	for ; n != nil; n = n.Right {
+		it.stack = append(it.stack, n)
+	}
+}
+
+// Ascend iterates over the tree items in the order defined by the comparator, until the callback
+// function returns false. Unlike Traverse, the tree lock is not held while the callback is running,
+// so the callback can call the other tree methods without a deadlock. The callback receives a copy
+// of the item, but if the tree is modified during the iteration, some items might be skipped or visited twice.
+func (b *BsTree[K, V]) Ascend(fn func(Item[K, V]) bool) {
+	b.iterate(fn, (*Iterator[K, V]).First, (*Iterator[K, V]).Next)
+}
+
+// Descend iterates over the tree items in reverse order, until the callback function returns false.
+// Like in case of Ascend, the tree lock is not held while the callback is running.
+func (b *BsTree[K, V]) Descend(fn func(Item[K, V]) bool) {
+	b.iterate(fn, (*Iterator[K, V]).Last, (*Iterator[K, V]).Prev)
+}
+
+// iterate calls the callback function for each item visited by the iterator.
+func (b *BsTree[K, V]) iterate(fn func(Item[K, V]) bool, start, step func(*Iterator[K, V]) bool) {
+	it := b.Iterator()
+	for ok := start(it); ok; ok = step(it) {
+		if !fn(it.Item()) {
+			return
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package bstree
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterator(t *testing.T) {
+	assert := assert.New(t)
+
+	bst := New[int, int](func(a, b int) bool { return a < b })
+	it := bst.Iterator()
+	assert.False(it.First())
+	assert.False(it.Last())
+	assert.False(it.Seek(1))
+	assert.False(it.Next())
+	assert.False(it.Prev())
+	assert.Equal(Item[int, int]{}, it.Item())
+
+	keys := rand.Perm(500)
+	for _, k := range keys {
+		bst.Upsert(k*2, k)
+	}
+	sort.Ints(keys)
+
+	i := 0
+	for ok := it.First(); ok; ok = it.Next() {
+		assert.Equal(keys[i]*2, it.Item().Key)
+		i++
+	}
+	assert.Equal(len(keys), i)
+	assert.False(it.Valid())
+
+	for ok := it.Last(); ok; ok = it.Prev() {
+		i--
+		assert.Equal(keys[i]*2, it.Item().Key)
+	}
+	assert.Equal(0, i)
+
+	// Seek positions the iterator on the exact or the next key.
+	assert.True(it.Seek(100))
+	assert.Equal(100, it.Item().Key)
+	assert.True(it.Seek(101))
+	assert.Equal(102, it.Item().Key)
+	assert.True(it.Prev())
+	assert.Equal(100, it.Item().Key)
+	assert.True(it.Next())
+	assert.True(it.Next())
+	assert.Equal(104, it.Item().Key)
+	assert.True(it.Seek(-10))
+	assert.Equal(0, it.Item().Key)
+	assert.False(it.Seek(999))
+
+	// The tree can be accessed between the iteration steps.
+	it.First()
+	_, err := bst.Get(it.Item().Key)
+	assert.NoError(err)
+}
+
+func TestAscendDescend(t *testing.T) {
+	assert := assert.New(t)
+
+	type tree interface {
+		Upsert(key int, val string)
+		Get(key int) (Item[int, string], error)
+		Ascend(fn func(Item[int, string]) bool)
+		Descend(fn func(Item[int, string]) bool)
+	}
+
+	for _, bst := range []tree{
+		New[int, string](func(a, b int) bool { return a < b }),
+		NewAVL[int, string](func(a, b int) bool { return a < b }),
+	} {
+		for _, k := range rand.Perm(100) {
+			bst.Upsert(k, fmt.Sprint(k))
+		}
+
+		var keys []int
+		bst.Ascend(func(item Item[int, string]) bool {
+			// The callback can call back into the tree.
+			node, err := bst.Get(item.Key)
+			assert.NoError(err)
+			assert.Equal(item.Val, node.Val)
+
+			keys = append(keys, item.Key)
+			return item.Key < 9
+		})
+		assert.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, keys)
+
+		keys = keys[:0]
+		bst.Descend(func(item Item[int, string]) bool {
+			keys = append(keys, item.Key)
+			return len(keys) < 3
+		})
+		assert.Equal([]int{99, 98, 97}, keys)
+	}
+}
+
+func Example_iterator() {
+	bst := New[int, string](func(a, b int) bool { return a < b })
+	for i, v := range []string{"foo", "bar", "baz", "qux"} {
+		bst.Upsert(i*10, v)
+	}
+
+	vals := []string{}
+	it := bst.Iterator()
+	for ok := it.Seek(15); ok; ok = it.Next() {
+		vals = append(vals, it.Item().Val)
+	}
+	fmt.Println(vals)
+
+	keys := []int{}
+	bst.Descend(func(item Item[int, string]) bool {
+		keys = append(keys, item.Key)
+		return item.Key > 10
+	})
+	fmt.Println(keys)
+
+	// Output:
+	// [baz qux]
+	// [30 20 10]
+}
+
+func TestIterator_ConcurrentUpsert(t *testing.T) {
+	assert := assert.New(t)
+
+	bst := New[int, int](func(a, b int) bool { return a < b })
+	for i := 0; i < 100; i++ {
+		bst.Upsert(i, i)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			bst.Upsert(i%100, i)
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		n := 0
+		bst.Ascend(func(item Item[int, int]) bool {
+			assert.Equal(item.Key, item.Val%100)
+			n++
+			return true
+		})
+		assert.Equal(100, n)
+	}
+	wg.Wait()
+}
+
+func BenchmarkTraversal(b *testing.B) {
+	bst := New[int, int](func(a, b int) bool { return a < b })
+	for _, k := range rand.Perm(10000) {
+		bst.Upsert(k, k)
+	}
+
+	b.Run("Traverse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bst.Traverse(func(Item[int, int]) {})
+		}
+	})
+	b.Run("Ascend", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bst.Ascend(func(Item[int, int]) bool { return true })
+		}
+	})
+	b.Run("Iterator", func(b *testing.B) {
+		it := bst.Iterator()
+		for i := 0; i < b.N; i++ {
+			for ok := it.First(); ok; ok = it.Next() {
+			}
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package bstree
+
+import (
+	"github.com/esimov/gogu"
+)
+
+// Min returns the item with the smallest key, considering the order defined by the comparator.
+// It returns an error if the tree is empty.
+func (b *BsTree[K, V]) Min() (Item[K, V], error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.root == nil {
+		var it Item[K, V]
+		return it, ErrorNotFound
+	}
+	return b.root.min().Item, nil
+}
+
+// Max returns the item with the largest key, considering the order defined by the comparator.
+// It returns an error if the tree is empty.
+func (b *BsTree[K, V]) Max() (Item[K, V], error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.root == nil {
+		var it Item[K, V]
+		return it, ErrorNotFound
+	}
+	return b.root.max().Item, nil
+}
+
+// Floor returns the item with the largest key less than or equal to the provided key.
+// It returns an error if there is no such item.
+func (b *BsTree[K, V]) Floor(key K) (Item[K, V], error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.lower(key, true)
+}
+
+// Ceiling returns the item with the smallest key greater than or equal to the provided key.
+// It returns an error if there is no such item.
+func (b *BsTree[K, V]) Ceiling(key K) (Item[K, V], error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.upper(key, true)
+}
+
+// Predecessor returns the item with the largest key strictly less than the provided key.
+// The key itself does not need to exist in the tree. It returns an error if there is no such item.
+func (b *BsTree[K, V]) Predecessor(key K) (Item[K, V], error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.lower(key, false)
+}
+
+// Successor returns the item with the smallest key strictly greater than the provided key.
+// The key itself does not need to exist in the tree. It returns an error if there is no such item.
+func (b *BsTree[K, V]) Successor(key K) (Item[K, V], error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.upper(key, false)
+}
+
+// Range returns the items having the keys between lo and hi inclusively, in the order defined by the comparator.
+func (b *BsTree[K, V]) Range(lo, hi K) []Item[K, V] {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	items := []Item[K, V]{}
+	b.root.collect(b, lo, hi, &items)
+
+	return items
+}
+
+// Rank returns the number of keys strictly less than the provided key.
+// The key itself does not need to exist in the tree. It runs in O(height) time.
+func (b *BsTree[K, V]) Rank(key K) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	rank := 0
+	for n := b.root; n != nil; {
+		switch gogu.Compare(key, n.Key, b.comp) {
+		case 1:
+			n = n.Left
+		case -1:
+			rank += 1 + n.Left.length()
+			n = n.Right
+		default:
+			return rank + n.Left.length()
+		}
+	}
+
+	return rank
+}
+
+// Select returns the item with the provided rank, which means the i-th smallest item starting from zero.
+// It returns an error if the rank is out of range. It runs in O(height) time.
+func (b *BsTree[K, V]) Select(i int) (Item[K, V], error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for n := b.root; n != nil; {
+		left := n.Left.length()
+		switch {
+		case i < left:
+			n = n.Left
+		case i > left:
+			i -= left + 1
+			n = n.Right
+		default:
+			return n.Item, nil
+		}
+	}
+
+	var it Item[K, V]
+	return it, ErrorNotFound
+}
+
+// max searches for the latest node on the right branch, which holds the largest value.
+func (n *Node[K, V]) max() *Node[K, V] {
+	for ; n.Right != nil; n = n.Right {
+	}
+	return n
+}
+
+// lower returns the item with the largest key less than the provided key,
+// or equal to it if inclusive is true.
+func (b *BsTree[K, V]) lower(key K, inclusive bool) (Item[K, V], error) {
+	var found *Node[K, V]
+	for n := b.root; n != nil; {
+		switch gogu.Compare(key, n.Key, b.comp) {
+		case 1:
+			n = n.Left
+		case -1:
+			found = n
+			n = n.Right
+		default:
+			if inclusive {
+				return n.Item, nil
+			}
+			n = n.Left
+		}
+	}
+
+	if found == nil {
+		var it Item[K, V]
+		return it, ErrorNotFound
+	}
+	return found.Item, nil
+}
+
+// upper returns the item with the smallest key greater than the provided key,
+// or equal to it if inclusive is true.
+func (b *BsTree[K, V]) upper(key K, inclusive bool) (Item[K, V], error) {
+	var found *Node[K, V]
+	for n := b.root; n != nil; {
+		switch gogu.Compare(key, n.Key, b.comp) {
+		case 1:
+			found = n
+			n = n.Left
+		case -1:
+			n = n.Right
+		default:
+			if inclusive {
+				return n.Item, nil
+			}
+			n = n.Right
+		}
+	}
+
+	if found == nil {
+		var it Item[K, V]
+		return it, ErrorNotFound
+	}
+	return found.Item, nil
+}
+
+// collect appends the items of the subtree having the keys between lo and hi to the items slice,
+// pruning the branches which are out of range.
+func (n *Node[K, V]) collect(b *BsTree[K, V], lo, hi K, items *[]Item[K, V]) {
+	if n == nil {
+		return
+	}
+	if b.comp(lo, n.Key) {
+		n.Left.collect(b, lo, hi, items)
+	}
+	if !b.comp(n.Key, lo) && !b.comp(hi, n.Key) {
+		*items = append(*items, n.Item)
+	}
+	if b.comp(n.Key, hi) {
+		n.Right.collect(b, lo, hi, items)
+	}
+}
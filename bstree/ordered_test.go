@@ -0,0 +1,164 @@
+package bstree
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBSTree_MinMax(t *testing.T) {
+	assert := assert.New(t)
+
+	bst := New[int, string](func(a, b int) bool { return a < b })
+	_, err := bst.Min()
+	assert.ErrorIs(err, ErrorNotFound)
+	_, err = bst.Max()
+	assert.ErrorIs(err, ErrorNotFound)
+
+	for _, k := range []int{5, 3, 8, 1, 4, 9} {
+		bst.Upsert(k, fmt.Sprint(k))
+	}
+	min, err := bst.Min()
+	assert.NoError(err)
+	assert.Equal(1, min.Key)
+	max, err := bst.Max()
+	assert.NoError(err)
+	assert.Equal(9, max.Key)
+
+	// The order is defined by the comparator.
+	desc := New[int, string](func(a, b int) bool { return a > b })
+	for _, k := range []int{5, 3, 8} {
+		desc.Upsert(k, fmt.Sprint(k))
+	}
+	min, _ = desc.Min()
+	assert.Equal(8, min.Key)
+}
+
+func TestBSTree_FloorCeiling(t *testing.T) {
+	assert := assert.New(t)
+
+	bst := New[int, int](func(a, b int) bool { return a < b })
+	for _, k := range []int{10, 20, 30, 40, 50} {
+		bst.Upsert(k, k)
+	}
+
+	testCases := []struct {
+		key                                 int
+		floor, ceiling, pred, succ          int
+		floorErr, ceilErr, predErr, succErr bool
+	}{
+		{key: 5, ceiling: 10, succ: 10, floorErr: true, predErr: true},
+		{key: 10, floor: 10, ceiling: 10, succ: 20, predErr: true},
+		{key: 25, floor: 20, ceiling: 30, pred: 20, succ: 30},
+		{key: 30, floor: 30, ceiling: 30, pred: 20, succ: 40},
+		{key: 50, floor: 50, ceiling: 50, pred: 40, succErr: true},
+		{key: 55, floor: 50, pred: 50, ceilErr: true, succErr: true},
+	}
+	for _, tc := range testCases {
+		check := func(item Item[int, int], err error, expected int, expectErr bool) {
+			if expectErr {
+				assert.ErrorIs(err, ErrorNotFound, "key %d", tc.key)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(expected, item.Key, "key %d", tc.key)
+		}
+		item, err := bst.Floor(tc.key)
+		check(item, err, tc.floor, tc.floorErr)
+		item, err = bst.Ceiling(tc.key)
+		check(item, err, tc.ceiling, tc.ceilErr)
+		item, err = bst.Predecessor(tc.key)
+		check(item, err, tc.pred, tc.predErr)
+		item, err = bst.Successor(tc.key)
+		check(item, err, tc.succ, tc.succErr)
+	}
+}
+
+func TestBSTree_Range(t *testing.T) {
+	assert := assert.New(t)
+
+	bst := New[int, int](func(a, b int) bool { return a < b })
+	for _, k := range rand.Perm(100) {
+		bst.Upsert(k, k*2)
+	}
+
+	items := bst.Range(10, 14)
+	assert.Len(items, 5)
+	for i, item := range items {
+		assert.Equal(10+i, item.Key)
+		assert.Equal(2*(10+i), item.Val)
+	}
+	assert.Len(bst.Range(-10, 200), 100)
+	assert.Empty(bst.Range(200, 300))
+	assert.Empty(bst.Range(14, 10))
+}
+
+func TestBSTree_RankSelect(t *testing.T) {
+	assert := assert.New(t)
+
+	bst := New[int, int](func(a, b int) bool { return a < b })
+	keys := make(map[int]struct{})
+	for i := 0; i < 200; i++ {
+		k := rand.Intn(500)
+		bst.Upsert(k, k)
+		keys[k] = struct{}{}
+	}
+	// Delete some keys to check that the subtree sizes are maintained.
+	for k := range keys {
+		if k%3 == 0 {
+			assert.NoError(bst.Delete(k))
+			delete(keys, k)
+		}
+	}
+	assert.Error(bst.Delete(-1))
+	assert.Equal(len(keys), bst.Size())
+
+	sorted := make([]int, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Ints(sorted)
+
+	for i, k := range sorted {
+		assert.Equal(i, bst.Rank(k))
+		item, err := bst.Select(i)
+		assert.NoError(err)
+		assert.Equal(k, item.Key)
+	}
+	assert.Equal(0, bst.Rank(-1))
+	assert.Equal(len(sorted), bst.Rank(1000))
+
+	_, err := bst.Select(-1)
+	assert.Error(err)
+	_, err = bst.Select(len(sorted))
+	assert.Error(err)
+}
+
+func Example_ordered() {
+	bst := New[int, string](func(a, b int) bool { return a < b })
+	bst.Upsert(10, "foo")
+	bst.Upsert(20, "bar")
+	bst.Upsert(30, "baz")
+	bst.Upsert(40, "qux")
+
+	floor, _ := bst.Floor(25)
+	ceiling, _ := bst.Ceiling(25)
+	fmt.Println(floor.Val, ceiling.Val)
+
+	fmt.Println(bst.Rank(30))
+	item, _ := bst.Select(3)
+	fmt.Println(item.Val)
+
+	for _, item := range bst.Range(15, 35) {
+		fmt.Print(item.Key, " ")
+	}
+
+	// Output:
+	// bar baz
+	// 2
+	// qux
+	// 20 30
+}
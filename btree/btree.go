@@ -4,55 +4,188 @@
 // compared to the standard BST where each node has only two leaves.
 // The implementation is an adapted version of https://algs4.cs.princeton.edu/62btree/BTree.java.
 //
-// This package is NOT thread-safe.
-// For data consistency some sort of concurrency safe mechanism should be implemented on the client side.
+// The B-tree is safe for concurrent use. It also supports cheap copy-on-write snapshots,
+// which makes possible to iterate over a consistent view of the tree while it's being modified.
 package btree
 
 import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
 	"github.com/esimov/gogu"
 	"golang.org/x/exp/constraints"
 )
 
-// Max children per binary tree. Must be even or greater than 2.
-const maxChildren = 4
+// DefaultOrder is the default maximum number of children per node.
+const DefaultOrder = 4
+
+// Item is a key-value pair used for bulk loading the B-tree.
+type Item[K constraints.Ordered, V any] struct {
+	Key K
+	Val V
+}
 
 // entry is the inner component of a node, which holds the node value and a pointer to the next node.
+// The entries of the internal nodes hold the smallest key of the subtree they point to.
 type entry[K constraints.Ordered, V any] struct {
-	key       K
-	value     V
-	next      *node[K, V]
-	isRemoved bool
+	key   K
+	value V
+	next  *node[K, V]
 }
 
 // node is a data structure which defines how many children (leaves) each node has.
 type node[K constraints.Ordered, V any] struct {
-	children [maxChildren]entry[K, V]
+	children []entry[K, V]
 	m        int
+	// cow identifies the tree which owns the node and is allowed to modify it in place.
+	cow *cow
 }
 
-// newNode instantiates a new node with no leaves.
-func newNode[K constraints.Ordered, V any](m int) *node[K, V] {
-	return &node[K, V]{
-		m: m,
-	}
+// cow is the ownership token of the copy-on-write mechanism. The nodes shared between a tree and its clones
+// are owned by none of them, so they are copied by the tree which modifies them first.
+// It must not be a zero-sized type, because the pointers to distinct zero-sized values may be equal.
+type cow struct {
+	_ byte
 }
 
 // BTree defines a data structure with one node, which is the root node.
 type BTree[K constraints.Ordered, V any] struct {
+	mu     sync.RWMutex
 	root   *node[K, V]
 	n      int
 	height int
+	order  int
+	cow    *cow
+	// shared is set once a clone shares the nodes of the tree.
+	shared atomic.Bool
+	// pins is the number of scans in progress over the nodes of the tree.
+	pins atomic.Int32
 }
 
-// New creates a new B-tree.
+// New creates a new B-tree having the default order.
 func New[K constraints.Ordered, V any]() *BTree[K, V] {
+	return NewWithOrder[K, V](DefaultOrder)
+}
+
+// NewWithOrder creates a new B-tree having the provided order, which is the maximum number of children
+// per node. A higher order makes the tree shallower, at the expense of larger nodes. The order must be even
+// and at least 4, otherwise it's rounded up to the nearest valid value.
+func NewWithOrder[K constraints.Ordered, V any](order int) *BTree[K, V] {
+	if order < 4 {
+		order = 4
+	}
+	order += order % 2
+
+	t := &BTree[K, V]{
+		order: order,
+		cow:   new(cow),
+	}
+	t.root = t.newNode(0)
+
+	return t
+}
+
+// newNode instantiates a new node owned by the tree, with room for order entries, of which the first m are in use.
+func (t *BTree[K, V]) newNode(m int) *node[K, V] {
+	return &node[K, V]{
+		children: make([]entry[K, V], t.order),
+		m:        m,
+		cow:      t.cow,
+	}
+}
+
+// mutable returns a version of the node which can be modified in place by the tree.
+// If the node is shared with a clone, a copy of it is returned.
+func (t *BTree[K, V]) mutable(n *node[K, V]) *node[K, V] {
+	if n.cow == t.cow {
+		return n
+	}
+	c := t.newNode(n.m)
+	copy(c.children, n.children)
+
+	return c
+}
+
+// mutableChild makes the child at index j of the node modifiable by the tree and returns it.
+func (n *node[K, V]) mutableChild(t *BTree[K, V], j int) *node[K, V] {
+	child := t.mutable(n.children[j].next)
+	n.children[j].next = child
+
+	return child
+}
+
+// Clone returns a snapshot of the tree in constant time. The tree and its clone share the nodes until
+// one of them is modified, when the affected nodes are copied (copy-on-write). This means that the clone
+// is not affected by the later modifications of the tree and vice versa.
+func (t *BTree[K, V]) Clone() *BTree[K, V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	// The tree gives up the ownership of the shared nodes on its next modification.
+	t.shared.Store(true)
+	return t.view()
+}
+
+// view returns a tree sharing the nodes of t, which owns none of them.
+// It must be called while holding the tree lock.
+func (t *BTree[K, V]) view() *BTree[K, V] {
 	return &BTree[K, V]{
-		root: newNode[K, V](0),
+		root:   t.root,
+		n:      t.n,
+		height: t.height,
+		order:  t.order,
+		cow:    new(cow),
 	}
 }
 
+// snapshot returns a view of the tree for a scan, which stays unaffected by the modifications of the tree
+// until the returned release function is called. Unlike Clone, once the scan is over the tree keeps modifying
+// its nodes in place, unless it has been modified during the scan.
+func (t *BTree[K, V]) snapshot() (*BTree[K, V], func()) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	t.pins.Add(1)
+	return t.view(), func() { t.pins.Add(-1) }
+}
+
+// own makes the tree give up the ownership of its nodes if they are shared with a clone or a scan
+// in progress, so that they are copied before being modified. It must be called with the write lock held.
+func (t *BTree[K, V]) own() {
+	if t.shared.Swap(false) || t.pins.Load() > 0 {
+		t.cow = new(cow)
+	}
+}
+
+// BulkLoad creates a new B-tree having the provided order from a slice of items sorted in strictly ascending
+// order by their keys. The tree is built bottom-up in O(n) time, the nodes being packed as densely as possible.
+// It returns an error if the items are not sorted or they contain duplicate keys.
+func BulkLoad[K constraints.Ordered, V any](items []Item[K, V], order int) (*BTree[K, V], error) {
+	t := NewWithOrder[K, V](order)
+
+	entries := make([]entry[K, V], len(items))
+	for i, item := range items {
+		if i > 0 && !gogu.Less(items[i-1].Key, item.Key) {
+			return nil, fmt.Errorf("the items must be sorted in strictly ascending order: %v before %v", items[i-1].Key, item.Key)
+		}
+		entries[i] = entry[K, V]{key: item.Key, value: item.Val}
+	}
+	t.build(entries)
+
+	return t, nil
+}
+
+// Order returns the maximum number of children per node.
+func (t *BTree[K, V]) Order() int {
+	return t.order
+}
+
 // Size returns the B-tree size (the number of elements).
 func (t *BTree[K, V]) Size() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
 	return t.n
 }
 
@@ -63,12 +196,18 @@ func (t *BTree[K, V]) IsEmpty() bool {
 
 // Height returns the B-tree size (how many levels it has).
 func (t *BTree[K, V]) Height() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
 	return t.height
 }
 
 // Get searches for a key and in case it's found it returns the key's value
 // together with a boolean flag signaling the key existence in the tree data structure.
 func (t *BTree[K, V]) Get(key K) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
 	return t.root.search(t, key, t.height)
 }
 
@@ -95,14 +234,22 @@ func (n *node[K, V]) search(t *BTree[K, V], key K, height int) (V, bool) {
 }
 
 // Put inserts a new value into the B-tree.
+// If the key already exists, its value is overwritten.
 func (t *BTree[K, V]) Put(key K, val V) {
-	u := t.root.insert(t, key, val, t.height, false)
-	t.n++
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.own()
+	t.root = t.mutable(t.root)
+	u, added := t.root.insert(t, key, val, t.height)
+	if added {
+		t.n++
+	}
 	if u == nil {
 		return
 	}
 	// split the root
-	n := newNode[K, V](2)
+	n := t.newNode(2)
 	n.children[0] = entry[K, V]{
 		key:  t.root.children[0].key,
 		next: t.root,
@@ -116,8 +263,9 @@ func (t *BTree[K, V]) Put(key K, val V) {
 	t.height++
 }
 
-// insert is a private method which is invoked by the Put method.
-func (n *node[K, V]) insert(t *BTree[K, V], key K, val V, height int, isRemoved bool) *node[K, V] {
+// insert is a private method which is invoked by the Put method. It returns the new node resulted
+// from splitting n, if it's the case, and a flag signaling that a new key has been added.
+func (n *node[K, V]) insert(t *BTree[K, V], key K, val V, height int) (*node[K, V], bool) {
 	entry := entry[K, V]{
 		key:   key,
 		value: val,
@@ -131,9 +279,7 @@ func (n *node[K, V]) insert(t *BTree[K, V], key K, val V, height int, isRemoved
 			// If the value already exists in the B-tree this will be overwritten.
 			if gogu.Equal(key, n.children[j].key) {
 				n.children[j].value = val
-				// This signals that we are invoking the Put or Remove method.
-				n.children[j].isRemoved = isRemoved
-				return nil
+				return nil, false
 			} else if gogu.Less(key, n.children[j].key) {
 				break
 			}
@@ -142,9 +288,12 @@ func (n *node[K, V]) insert(t *BTree[K, V], key K, val V, height int, isRemoved
 		// internal node
 		for j = 0; j < n.m; j++ {
 			if j+1 == n.m || gogu.Less(key, n.children[j+1].key) {
-				node := n.children[j].next.insert(t, key, val, height-1, isRemoved)
+				node, added := n.mutableChild(t, j).insert(t, key, val, height-1)
+				if gogu.Less(key, n.children[j].key) {
+					n.children[j].key = key
+				}
 				if node == nil {
-					return nil
+					return nil, added
 				}
 				j++
 				entry.key = node.children[0].key
@@ -159,47 +308,215 @@ func (n *node[K, V]) insert(t *BTree[K, V], key K, val V, height int, isRemoved
 
 	n.children[j] = entry
 	n.m++
-	if n.m < maxChildren {
-		return nil
+	if n.m < t.order {
+		return nil, true
 	} else {
-		return t.split(n)
+		return t.split(n), true
 	}
 }
 
 func (t *BTree[K, V]) split(n *node[K, V]) *node[K, V] {
-	h := newNode[K, V](maxChildren / 2)
-	n.m = maxChildren / 2
+	h := t.newNode(t.order / 2)
+	n.m = t.order / 2
 
 	for i := 0; i < n.m; i++ {
 		h.children[i] = n.children[n.m+i]
+		n.children[n.m+i] = entry[K, V]{}
 	}
 	return h
 }
 
-// Remove deletes a node from the B-tree.
+// Remove deletes a key from the B-tree. The nodes left with less than half of the maximum entries
+// borrow entries from their siblings or they are merged with them, and the root is removed
+// once it has a single child, which means that the tree height shrinks as the tree gets smaller.
 func (t *BTree[K, V]) Remove(key K) {
-	val, ok := t.Get(key)
-	if !ok {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.own()
+	t.root = t.mutable(t.root)
+	if !t.root.remove(t, key, t.height) {
 		return
 	}
 	t.n--
-	t.root.insert(t, key, val, t.height, true)
+
+	// shrink the root
+	if t.height > 0 && t.root.m == 1 {
+		t.root = t.root.children[0].next
+		t.height--
+	}
+}
+
+// remove is a private method which is invoked by the Remove method.
+// It returns false if the key has not been found.
+func (n *node[K, V]) remove(t *BTree[K, V], key K, height int) bool {
+	// external node
+	if height == 0 {
+		for j := 0; j < n.m; j++ {
+			if gogu.Equal(key, n.children[j].key) {
+				n.delete(j)
+				return true
+			}
+		}
+		return false
+	}
+
+	// internal node
+	for j := 0; j < n.m; j++ {
+		if j+1 == n.m || gogu.Less(key, n.children[j+1].key) {
+			child := n.mutableChild(t, j)
+			if !child.remove(t, key, height-1) {
+				return false
+			}
+			if child.m > 0 {
+				n.children[j].key = child.children[0].key
+			}
+			if child.m < t.order/2 {
+				n.rebalance(t, j)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// rebalance fixes the child at index j, which has less than the minimum number of entries,
+// by borrowing an entry from one of its siblings or merging it with a sibling.
+func (n *node[K, V]) rebalance(t *BTree[K, V], j int) {
+	child := n.children[j].next
+
+	// borrow from the left sibling
+	if j > 0 {
+		left := n.children[j-1].next
+		if left.m > t.order/2 {
+			left = n.mutableChild(t, j-1)
+			child.insertAt(0, left.children[left.m-1])
+			left.delete(left.m - 1)
+			n.children[j].key = child.children[0].key
+			return
+		}
+	}
+	// borrow from the right sibling
+	if j+1 < n.m {
+		right := n.children[j+1].next
+		if right.m > t.order/2 {
+			right = n.mutableChild(t, j+1)
+			child.insertAt(child.m, right.children[0])
+			right.delete(0)
+			n.children[j+1].key = right.children[0].key
+			return
+		}
+	}
+
+	// merge with a sibling
+	if j > 0 {
+		n.mutableChild(t, j-1).merge(child)
+		n.delete(j)
+	} else if j+1 < n.m {
+		child.merge(n.children[j+1].next)
+		n.delete(j + 1)
+	}
+}
+
+// insertAt inserts the entry at index j, shifting the following entries to the right.
+func (n *node[K, V]) insertAt(j int, e entry[K, V]) {
+	for i := n.m; i > j; i-- {
+		n.children[i] = n.children[i-1]
+	}
+	n.children[j] = e
+	n.m++
+}
+
+// delete removes the entry at index j, shifting the following entries to the left.
+func (n *node[K, V]) delete(j int) {
+	for i := j; i < n.m-1; i++ {
+		n.children[i] = n.children[i+1]
+	}
+	n.m--
+	// release the references held by the vacated entry
+	n.children[n.m] = entry[K, V]{}
+}
+
+// merge moves all the entries of the sibling node to the end of n.
+func (n *node[K, V]) merge(sibling *node[K, V]) {
+	for i := 0; i < sibling.m; i++ {
+		n.children[n.m] = sibling.children[i]
+		n.m++
+	}
+}
+
+// Compact rebuilds the tree bottom-up, packing the entries into as few nodes as possible.
+// The deletions leave the nodes half-full in the worst case, so compacting a tree which has shrunk
+// considerably reduces its memory footprint and possibly its height.
+func (t *BTree[K, V]) Compact() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entries := make([]entry[K, V], 0, t.n)
+	t.each(t.root, t.height, func(e entry[K, V]) {
+		entries = append(entries, entry[K, V]{key: e.key, value: e.value})
+	})
+	t.build(entries)
+}
+
+// build replaces the content of the tree with the sorted leaf entries,
+// building the tree level by level from the leaves up to the root.
+func (t *BTree[K, V]) build(entries []entry[K, V]) {
+	t.root, t.height, t.n = t.newNode(0), 0, len(entries)
+	if len(entries) == 0 {
+		return
+	}
+
+	for {
+		nodes := t.pack(entries)
+		if len(nodes) == 1 {
+			t.root = nodes[0]
+			return
+		}
+		entries = make([]entry[K, V], len(nodes))
+		for i, n := range nodes {
+			entries[i] = entry[K, V]{key: n.children[0].key, next: n}
+		}
+		t.height++
+	}
+}
+
+// pack distributes the sorted entries evenly into the minimum number of nodes.
+// Each node receives at least the minimum number of entries, except when a single node is created.
+func (t *BTree[K, V]) pack(entries []entry[K, V]) []*node[K, V] {
+	max := t.order - 1
+	count := (len(entries) + max - 1) / max
+	nodes := make([]*node[K, V], count)
+
+	start := 0
+	for i := range nodes {
+		size := len(entries) / count
+		if i < len(entries)%count {
+			size++
+		}
+		n := t.newNode(size)
+		copy(n.children, entries[start:start+size])
+		nodes[i] = n
+		start += size
+	}
+
+	return nodes
 }
 
 // Traverse iterates over the tree nodes and invokes the callback function provided as argument.
+// The iteration runs on a snapshot of the tree, so the callback function can safely access and modify the tree.
 func (t *BTree[K, V]) Traverse(fn func(key K, val V)) {
-	t.traverse(t.root, t.height, fn)
+	s, release := t.snapshot()
+	defer release()
+
+	s.traverse(s.root, s.height, fn)
 }
 
 func (t *BTree[K, V]) traverse(n *node[K, V], depth int, fn func(K, V)) {
 	// external node
 	if depth == 0 {
 		for i := 0; i < n.m; i++ {
-			l := n.children[i]
-			if l.isRemoved {
-				continue
-			}
-			fn(l.key, l.value)
+			fn(n.children[i].key, n.children[i].value)
 		}
 	} else {
 		// internal node
@@ -208,3 +525,16 @@ func (t *BTree[K, V]) traverse(n *node[K, V], depth int, fn func(K, V)) {
 		}
 	}
 }
+
+// each invokes the callback function for every leaf entry of the subtree, in sorted order.
+func (t *BTree[K, V]) each(n *node[K, V], depth int, fn func(entry[K, V])) {
+	if depth == 0 {
+		for i := 0; i < n.m; i++ {
+			fn(n.children[i])
+		}
+		return
+	}
+	for i := 0; i < n.m; i++ {
+		t.each(n.children[i].next, depth-1, fn)
+	}
+}
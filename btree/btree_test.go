@@ -39,14 +39,23 @@ func TestBTree(t *testing.T) {
 
 	assert.Equal(n, btree.Size())
 
+	keys := []int{}
 	btree.Traverse(func(key, val int) {
 		v, found := btree.Get(key)
 		assert.True(found)
 		assert.Equal(v, val)
 
+		keys = append(keys, key)
+	})
+	assert.Len(keys, n)
+
+	for _, key := range keys {
 		btree.Remove(key)
 		delete(tmp, key)
-	})
+
+		_, found := btree.Get(key)
+		assert.False(found)
+	}
 
 	assert.Empty(btree.Size())
 	assert.True(btree.IsEmpty())
@@ -0,0 +1,153 @@
+package btree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBTree_Order(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(DefaultOrder, New[int, int]().Order())
+	assert.Equal(4, NewWithOrder[int, int](1).Order())
+	assert.Equal(6, NewWithOrder[int, int](5).Order())
+
+	for _, order := range []int{4, 6, 16, 64} {
+		btree := NewWithOrder[int, int](order)
+		n := 3000
+		for _, k := range rand.Perm(n) {
+			btree.Put(k, k)
+		}
+		checkTree(assert, btree)
+		assert.Equal(n, btree.Size())
+
+		for _, k := range rand.Perm(n)[:n/2] {
+			btree.Remove(k)
+		}
+		checkTree(assert, btree)
+		assert.Equal(n/2, btree.Size())
+	}
+
+	// A higher order makes the tree shallower.
+	small, large := NewWithOrder[int, int](4), NewWithOrder[int, int](64)
+	for i := 0; i < 1000; i++ {
+		small.Put(i, i)
+		large.Put(i, i)
+	}
+	assert.Less(large.Height(), small.Height())
+}
+
+func TestBulkLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, order := range []int{4, 8, 32} {
+		for _, n := range []int{0, 1, 3, 4, 10, 1000} {
+			items := make([]Item[int, string], n)
+			for i := range items {
+				items[i] = Item[int, string]{Key: i * 2, Val: fmt.Sprint(i)}
+			}
+
+			btree, err := BulkLoad(items, order)
+			assert.NoError(err)
+			assert.Equal(n, btree.Size())
+			assert.Equal(order, btree.Order())
+
+			i := 0
+			btree.Traverse(func(key int, val string) {
+				assert.Equal(items[i].Key, key)
+				assert.Equal(items[i].Val, val)
+				i++
+			})
+			assert.Equal(n, i)
+
+			// The bulk loaded tree remains fully functional.
+			btree.Put(1, "odd")
+			btree.Remove(0)
+			v, found := btree.Get(1)
+			assert.True(found)
+			assert.Equal("odd", v)
+			_, found = btree.Get(0)
+			assert.False(found)
+		}
+	}
+
+	_, err := BulkLoad([]Item[int, int]{{Key: 2}, {Key: 1}}, 4)
+	assert.Error(err)
+	_, err = BulkLoad([]Item[int, int]{{Key: 1}, {Key: 1}}, 4)
+	assert.Error(err)
+}
+
+func TestBulkLoad_Packed(t *testing.T) {
+	assert := assert.New(t)
+
+	n, order := 10000, 8
+	items := make([]Item[int, int], n)
+	for i := range items {
+		items[i] = Item[int, int]{Key: i, Val: i}
+	}
+	packed, err := BulkLoad(items, order)
+	assert.NoError(err)
+	checkTree(assert, packed)
+
+	incremental := NewWithOrder[int, int](order)
+	for _, item := range items {
+		incremental.Put(item.Key, item.Val)
+	}
+	assert.LessOrEqual(packed.Height(), incremental.Height())
+
+	// The leaves are filled up to the maximum number of entries.
+	var leaves func(n *node[int, int], height int) int
+	leaves = func(n *node[int, int], height int) int {
+		if height == 0 {
+			return 1
+		}
+		count := 0
+		for i := 0; i < n.m; i++ {
+			count += leaves(n.children[i].next, height-1)
+		}
+		return count
+	}
+	assert.Equal((n+order-2)/(order-1), leaves(packed.root, packed.height))
+	assert.Greater(leaves(incremental.root, incremental.height), leaves(packed.root, packed.height))
+}
+
+func Example_bulkLoad() {
+	items := []Item[int, string]{
+		{Key: 1, Val: "foo"},
+		{Key: 2, Val: "bar"},
+		{Key: 3, Val: "baz"},
+	}
+	btree, _ := BulkLoad(items, 8)
+	fmt.Println(btree.Size(), btree.Order())
+
+	val, _ := btree.Get(2)
+	fmt.Println(val)
+
+	// Output:
+	// 3 8
+	// bar
+}
+
+func BenchmarkBulkLoad(b *testing.B) {
+	items := make([]Item[int, int], 100000)
+	for i := range items {
+		items[i] = Item[int, int]{Key: i, Val: i}
+	}
+
+	b.Run("Put", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			btree := NewWithOrder[int, int](32)
+			for _, item := range items {
+				btree.Put(item.Key, item.Val)
+			}
+		}
+	})
+	b.Run("BulkLoad", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			BulkLoad(items, 32)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package btree
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBTree_Clone(t *testing.T) {
+	assert := assert.New(t)
+
+	bt := NewWithOrder[int, int](6)
+	for i := 0; i < 1000; i++ {
+		bt.Put(i, i)
+	}
+
+	snap := bt.Clone()
+	assert.Equal(1000, snap.Size())
+	assert.Equal(bt.Height(), snap.Height())
+	assert.Equal(bt.Order(), snap.Order())
+
+	// Modify the original tree, the snapshot should not be affected.
+	for _, i := range rand.Perm(1000)[:600] {
+		bt.Remove(i)
+	}
+	for i := 1000; i < 1500; i++ {
+		bt.Put(i, i)
+	}
+	for i := 0; i < 1000; i += 3 {
+		bt.Put(i, -i)
+	}
+	checkTree(assert, bt)
+	checkTree(assert, snap)
+
+	assert.Equal(1000, snap.Size())
+	for i := 0; i < 1000; i++ {
+		val, ok := snap.Get(i)
+		assert.True(ok)
+		assert.Equal(i, val)
+	}
+	_, ok := snap.Get(1200)
+	assert.False(ok)
+
+	// Modify the snapshot, the original tree should not be affected.
+	before := map[int]int{}
+	bt.Traverse(func(key, val int) {
+		before[key] = val
+	})
+	for i := 0; i < 1000; i += 2 {
+		snap.Remove(i)
+	}
+	snap.Put(5000, 5000)
+	checkTree(assert, bt)
+	checkTree(assert, snap)
+
+	assert.Equal(501, snap.Size())
+	assert.Equal(len(before), bt.Size())
+	for key, val := range before {
+		v, ok := bt.Get(key)
+		assert.True(ok)
+		assert.Equal(val, v)
+	}
+	_, ok = bt.Get(5000)
+	assert.False(ok)
+
+	// Clones of clones are independent as well.
+	c1 := snap.Clone()
+	c2 := c1.Clone()
+	c1.Compact()
+	c2.Remove(1)
+	checkTree(assert, c1)
+	checkTree(assert, c2)
+	assert.Equal(501, c1.Size())
+	assert.Equal(500, c2.Size())
+	assert.Equal(501, snap.Size())
+}
+
+func TestBTree_ScanWhileModifying(t *testing.T) {
+	assert := assert.New(t)
+
+	bt := New[int, int]()
+	for i := 0; i < 100; i++ {
+		bt.Put(i, i)
+	}
+
+	// The scan runs on a snapshot, so the tree can be modified from the callback.
+	keys := []int{}
+	bt.Ascend(func(key, val int) bool {
+		keys = append(keys, key)
+		bt.Remove(key)
+		bt.Put(key+100, val)
+		return true
+	})
+	assert.Len(keys, 100)
+	assert.Equal(99, keys[99])
+	assert.Equal(100, bt.Size())
+	k, _, _ := bt.Min()
+	assert.Equal(100, k)
+
+	c := bt.Cursor()
+	bt.Put(-1, -1)
+	assert.True(c.First())
+	assert.Equal(100, c.Item().Key)
+	checkTree(assert, bt)
+}
+
+func TestBTree_ScanKeepsOwnership(t *testing.T) {
+	assert := assert.New(t)
+
+	bt := New[int, int]()
+	for i := 0; i < 100; i++ {
+		bt.Put(i, i)
+	}
+
+	// The nodes are not copied on the modifications following a finished scan.
+	root := bt.root
+	bt.Ascend(func(key, val int) bool { return true })
+	bt.Traverse(func(key, val int) {})
+	bt.Put(1000, 1000)
+	assert.Same(root, bt.root)
+
+	// But they are copied once the tree is cloned.
+	c := bt.Clone()
+	bt.Put(1001, 1001)
+	assert.NotSame(root, bt.root)
+	assert.Same(root, c.root)
+	_, ok := c.Get(1001)
+	assert.False(ok)
+	checkTree(assert, bt)
+	checkTree(assert, c)
+}
+
+func TestBTree_Concurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	bt := New[int, int]()
+	wg := &sync.WaitGroup{}
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w * 1000; i < (w+1)*1000; i++ {
+				bt.Put(i, i)
+				if i%2 == 1 {
+					bt.Remove(i)
+				}
+			}
+		}(w)
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				bt.Get(i)
+				bt.Min()
+				bt.Max()
+				prev := -1
+				bt.Ascend(func(key, val int) bool {
+					assert.Less(prev, key)
+					prev = key
+					return true
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(2000, bt.Size())
+	checkTree(assert, bt)
+}
+
+func Example_clone() {
+	bt := New[string, int]()
+	bt.Put("a", 1)
+	bt.Put("b", 2)
+
+	snap := bt.Clone()
+	bt.Put("c", 3)
+	bt.Remove("a")
+
+	snap.Ascend(func(key string, val int) bool {
+		fmt.Println(key, val)
+		return true
+	})
+	fmt.Println(bt.Size(), snap.Size())
+
+	// Output:
+	// a 1
+	// b 2
+	// 2 2
+}
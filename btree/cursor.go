@@ -0,0 +1,150 @@
+package btree
+
+import (
+	"github.com/esimov/gogu"
+	"golang.org/x/exp/constraints"
+)
+
+// frame is a position on the path from the root to the current leaf entry.
+type frame[K constraints.Ordered, V any] struct {
+	n *node[K, V]
+	i int
+}
+
+// Cursor is a bidirectional iterator over the tree entries in ascending key order.
+// It keeps the path from the root to the current leaf entry, so moving to the next or the previous entry
+// takes amortized constant time. The cursor iterates over a snapshot of the tree taken at its creation,
+// which means that it's not affected by the later modifications of the tree.
+type Cursor[K constraints.Ordered, V any] struct {
+	t     *BTree[K, V]
+	stack []frame[K, V]
+}
+
+// Cursor returns a new cursor, which is not positioned on any entry yet.
+func (t *BTree[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{t: t.Clone()}
+}
+
+// Valid checks if the cursor is positioned on an entry.
+func (c *Cursor[K, V]) Valid() bool {
+	return len(c.stack) > 0
+}
+
+// Item returns the key and the value of the entry the cursor is positioned on,
+// or the zero value if the cursor is not valid.
+func (c *Cursor[K, V]) Item() Item[K, V] {
+	if !c.Valid() {
+		var item Item[K, V]
+		return item
+	}
+	f := c.stack[len(c.stack)-1]
+	return Item[K, V]{Key: f.n.children[f.i].key, Val: f.n.children[f.i].value}
+}
+
+// First positions the cursor on the entry with the smallest key.
+// It returns false if the tree is empty.
+func (c *Cursor[K, V]) First() bool {
+	c.stack = c.stack[:0]
+	if c.t.n == 0 {
+		return false
+	}
+	c.pushFirst(c.t.root, c.t.height)
+
+	return true
+}
+
+// Last positions the cursor on the entry with the largest key.
+// It returns false if the tree is empty.
+func (c *Cursor[K, V]) Last() bool {
+	c.stack = c.stack[:0]
+	if c.t.n == 0 {
+		return false
+	}
+	c.pushLast(c.t.root, c.t.height)
+
+	return true
+}
+
+// Seek positions the cursor on the entry with the smallest key greater than or equal to the provided key.
+// It returns false if there is no such entry.
+func (c *Cursor[K, V]) Seek(key K) bool {
+	c.stack = c.stack[:0]
+
+	n := c.t.root
+	for height := c.t.height; height > 0; height-- {
+		for j := 0; j < n.m; j++ {
+			if j+1 == n.m || gogu.Less(key, n.children[j+1].key) {
+				c.stack = append(c.stack, frame[K, V]{n, j})
+				n = n.children[j].next
+				break
+			}
+		}
+	}
+
+	for i := 0; i < n.m; i++ {
+		if !gogu.Less(n.children[i].key, key) {
+			c.stack = append(c.stack, frame[K, V]{n, i})
+			return true
+		}
+	}
+	// All the keys of the leaf are smaller, so the entry we are looking for is the first one of the next leaf.
+	c.stack = append(c.stack, frame[K, V]{n, n.m - 1})
+
+	return c.Next()
+}
+
+// Next moves the cursor to the entry with the next key. It returns false if there are no more entries,
+// in which case the cursor becomes invalid.
+func (c *Cursor[K, V]) Next() bool {
+	for len(c.stack) > 0 {
+		f := &c.stack[len(c.stack)-1]
+		if f.i+1 < f.n.m {
+			f.i++
+			// descend to the leftmost entry of the subtree under the next internal entry
+			if height := c.t.height - len(c.stack) + 1; height > 0 {
+				c.pushFirst(f.n.children[f.i].next, height-1)
+			}
+			return true
+		}
+		c.stack = c.stack[:len(c.stack)-1]
+	}
+
+	return false
+}
+
+// Prev moves the cursor to the entry with the previous key. It returns false if there are no more entries,
+// in which case the cursor becomes invalid.
+func (c *Cursor[K, V]) Prev() bool {
+	for len(c.stack) > 0 {
+		f := &c.stack[len(c.stack)-1]
+		if f.i > 0 {
+			f.i--
+			// descend to the rightmost entry of the subtree under the previous internal entry
+			if height := c.t.height - len(c.stack) + 1; height > 0 {
+				c.pushLast(f.n.children[f.i].next, height-1)
+			}
+			return true
+		}
+		c.stack = c.stack[:len(c.stack)-1]
+	}
+
+	return false
+}
+
+// pushFirst pushes the path to the leftmost leaf entry of the subtree.
+func (c *Cursor[K, V]) pushFirst(n *node[K, V], height int) {
+	for ; height > 0; height-- {
+		c.stack = append(c.stack, frame[K, V]{n, 0})
+		n = n.children[0].next
+	}
+	c.stack = append(c.stack, frame[K, V]{n, 0})
+}
+
+// pushLast pushes the path to the rightmost leaf entry of the subtree.
+func (c *Cursor[K, V]) pushLast(n *node[K, V], height int) {
+	for ; height > 0; height-- {
+		c.stack = append(c.stack, frame[K, V]{n, n.m - 1})
+		n = n.children[n.m-1].next
+	}
+	c.stack = append(c.stack, frame[K, V]{n, n.m - 1})
+}
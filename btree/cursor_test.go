@@ -0,0 +1,97 @@
+package btree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	assert := assert.New(t)
+
+	btree := New[int, int]()
+	c := btree.Cursor()
+	assert.False(c.First())
+	assert.False(c.Last())
+	assert.False(c.Seek(1))
+	assert.False(c.Next())
+	assert.False(c.Prev())
+	assert.Equal(Item[int, int]{}, c.Item())
+
+	for _, order := range []int{4, 10} {
+		btree = NewWithOrder[int, int](order)
+		n := 1000
+		for _, k := range rand.Perm(n) {
+			btree.Put(k*2, k)
+		}
+		c = btree.Cursor()
+
+		i := 0
+		for ok := c.First(); ok; ok = c.Next() {
+			assert.Equal(i*2, c.Item().Key)
+			assert.Equal(i, c.Item().Val)
+			i++
+		}
+		assert.Equal(n, i)
+		assert.False(c.Valid())
+
+		for ok := c.Last(); ok; ok = c.Prev() {
+			i--
+			assert.Equal(i*2, c.Item().Key)
+		}
+		assert.Equal(0, i)
+
+		// Seek positions the cursor on the exact or the next key.
+		for k := -1; k < 2*n-1; k++ {
+			assert.True(c.Seek(k))
+			// the keys are even, so the odd keys are rounded up
+			assert.Equal(k+(k&1), c.Item().Key)
+		}
+		assert.False(c.Seek(2 * n))
+
+		assert.True(c.Seek(101))
+		assert.True(c.Prev())
+		assert.Equal(100, c.Item().Key)
+		assert.True(c.Next())
+		assert.True(c.Next())
+		assert.Equal(104, c.Item().Key)
+	}
+}
+
+func TestCursor_Removed(t *testing.T) {
+	assert := assert.New(t)
+
+	btree := New[int, int]()
+	for i := 0; i < 100; i++ {
+		btree.Put(i, i)
+	}
+	for i := 0; i < 100; i += 2 {
+		btree.Remove(i)
+	}
+
+	var keys []int
+	c := btree.Cursor()
+	for ok := c.Seek(10); ok && c.Item().Key < 20; ok = c.Next() {
+		keys = append(keys, c.Item().Key)
+	}
+	assert.Equal([]int{11, 13, 15, 17, 19}, keys)
+}
+
+func Example_cursor() {
+	btree := New[int, string]()
+	for i, v := range []string{"foo", "bar", "baz", "qux"} {
+		btree.Put(i*10, v)
+	}
+
+	vals := []string{}
+	c := btree.Cursor()
+	for ok := c.Seek(15); ok; ok = c.Next() {
+		vals = append(vals, c.Item().Val)
+	}
+	fmt.Println(vals)
+
+	// Output:
+	// [baz qux]
+}
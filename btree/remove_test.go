@@ -0,0 +1,128 @@
+package btree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkTree verifies the B-tree invariants: the entries are sorted, the internal entries hold
+// the smallest key of their subtree, all the leaves are at the same depth and the nodes
+// other than the root hold at least the minimum number of entries.
+func checkTree(assert *assert.Assertions, t *BTree[int, int]) {
+	var check func(n *node[int, int], height int, isRoot bool) int
+	check = func(n *node[int, int], height int, isRoot bool) int {
+		if !isRoot {
+			assert.GreaterOrEqual(n.m, t.order/2)
+		}
+		assert.Less(n.m, t.order)
+		for i := 1; i < n.m; i++ {
+			assert.Less(n.children[i-1].key, n.children[i].key)
+		}
+
+		if height == 0 {
+			return n.m
+		}
+		count := 0
+		for i := 0; i < n.m; i++ {
+			child := n.children[i].next
+			assert.Equal(child.children[0].key, n.children[i].key)
+			count += check(child, height-1, false)
+		}
+		return count
+	}
+	assert.Equal(t.Size(), check(t.root, t.height, true))
+}
+
+func TestBTree_Remove(t *testing.T) {
+	assert := assert.New(t)
+
+	btree := New[int, int]()
+	btree.Remove(1)
+	assert.True(btree.IsEmpty())
+
+	n := 1000
+	for _, k := range rand.Perm(n) {
+		btree.Put(k, k)
+	}
+	// Overwriting an existing key does not change the size.
+	btree.Put(0, 0)
+	assert.Equal(n, btree.Size())
+	checkTree(assert, btree)
+	height := btree.Height()
+
+	tmp := make(map[int]struct{})
+	for i := 0; i < n; i++ {
+		tmp[i] = struct{}{}
+	}
+	for i, k := range rand.Perm(n) {
+		btree.Remove(k)
+		delete(tmp, k)
+
+		_, found := btree.Get(k)
+		assert.False(found)
+		assert.Equal(n-i-1, btree.Size())
+		if i%50 == 0 {
+			checkTree(assert, btree)
+			for k := range tmp {
+				v, found := btree.Get(k)
+				assert.True(found)
+				assert.Equal(k, v)
+			}
+		}
+	}
+	assert.True(btree.IsEmpty())
+	assert.Equal(0, btree.Height())
+	assert.Less(btree.Height(), height)
+
+	// Removing a missing key is a no-op.
+	btree.Put(1, 1)
+	btree.Remove(2)
+	assert.Equal(1, btree.Size())
+}
+
+func TestBTree_Compact(t *testing.T) {
+	assert := assert.New(t)
+
+	btree := New[int, int]()
+	btree.Compact()
+	assert.True(btree.IsEmpty())
+
+	n := 2000
+	for i := 0; i < n; i++ {
+		btree.Put(i, i*2)
+	}
+	for i := 0; i < n; i++ {
+		if i%4 != 0 {
+			btree.Remove(i)
+		}
+	}
+	height := btree.Height()
+	btree.Compact()
+	checkTree(assert, btree)
+	assert.Equal(n/4, btree.Size())
+	assert.LessOrEqual(btree.Height(), height)
+
+	var keys []int
+	btree.Traverse(func(key, val int) {
+		assert.Equal(key*2, val)
+		keys = append(keys, key)
+	})
+	assert.Len(keys, n/4)
+	assert.True(sort.IntsAreSorted(keys))
+
+	// The compacted tree remains fully functional.
+	for i := 0; i < n; i++ {
+		btree.Put(i, i*2)
+	}
+	checkTree(assert, btree)
+	for i := 0; i < n; i += 3 {
+		btree.Remove(i)
+	}
+	checkTree(assert, btree)
+	v, found := btree.Get(1)
+	assert.True(found)
+	assert.Equal(2, v)
+}
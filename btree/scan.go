@@ -0,0 +1,109 @@
+package btree
+
+import (
+	"github.com/esimov/gogu"
+)
+
+// Min returns the smallest key of the tree together with its value.
+// The returned boolean is false if the tree is empty.
+func (t *BTree[K, V]) Min() (K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	c := &Cursor[K, V]{t: t}
+	ok := c.First()
+	item := c.Item()
+
+	return item.Key, item.Val, ok
+}
+
+// Max returns the largest key of the tree together with its value.
+// The returned boolean is false if the tree is empty.
+func (t *BTree[K, V]) Max() (K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	c := &Cursor[K, V]{t: t}
+	ok := c.Last()
+	item := c.Item()
+
+	return item.Key, item.Val, ok
+}
+
+// scan returns a cursor over a snapshot of the tree, which is valid until the returned release function is called.
+func (t *BTree[K, V]) scan() (*Cursor[K, V], func()) {
+	s, release := t.snapshot()
+	return &Cursor[K, V]{t: s}, release
+}
+
+// Ascend invokes the callback function for each entry in ascending key order,
+// until the callback function returns false. Like all the scan methods, it iterates over a snapshot
+// of the tree, so the callback function can safely access and modify the tree.
+func (t *BTree[K, V]) Ascend(fn func(key K, val V) bool) {
+	c, release := t.scan()
+	defer release()
+	for ok := c.First(); ok; ok = c.Next() {
+		if item := c.Item(); !fn(item.Key, item.Val) {
+			return
+		}
+	}
+}
+
+// Descend invokes the callback function for each entry in descending key order,
+// until the callback function returns false.
+func (t *BTree[K, V]) Descend(fn func(key K, val V) bool) {
+	c, release := t.scan()
+	defer release()
+	for ok := c.Last(); ok; ok = c.Prev() {
+		if item := c.Item(); !fn(item.Key, item.Val) {
+			return
+		}
+	}
+}
+
+// AscendGreaterOrEqual invokes the callback function in ascending key order for each entry
+// having the key greater than or equal to the pivot, until the callback function returns false.
+func (t *BTree[K, V]) AscendGreaterOrEqual(pivot K, fn func(key K, val V) bool) {
+	c, release := t.scan()
+	defer release()
+	for ok := c.Seek(pivot); ok; ok = c.Next() {
+		if item := c.Item(); !fn(item.Key, item.Val) {
+			return
+		}
+	}
+}
+
+// AscendRange invokes the callback function in ascending key order for each entry in the range
+// [greaterOrEqual, lessThan), until the callback function returns false.
+func (t *BTree[K, V]) AscendRange(greaterOrEqual, lessThan K, fn func(key K, val V) bool) {
+	c, release := t.scan()
+	defer release()
+	for ok := c.Seek(greaterOrEqual); ok; ok = c.Next() {
+		item := c.Item()
+		if !gogu.Less(item.Key, lessThan) || !fn(item.Key, item.Val) {
+			return
+		}
+	}
+}
+
+// DescendRange invokes the callback function in descending key order for each entry in the range
+// [lessOrEqual, greaterThan), until the callback function returns false.
+func (t *BTree[K, V]) DescendRange(lessOrEqual, greaterThan K, fn func(key K, val V) bool) {
+	c, release := t.scan()
+	defer release()
+
+	// Position the cursor on the largest key less than or equal to the upper bound.
+	ok := c.Seek(lessOrEqual)
+	if !ok {
+		ok = c.Last()
+	} else if gogu.Less(lessOrEqual, c.Item().Key) {
+		ok = c.Prev()
+	}
+
+	for ; ok; ok = c.Prev() {
+		item := c.Item()
+		if !gogu.Less(greaterThan, item.Key) || !fn(item.Key, item.Val) {
+			return
+		}
+	}
+}
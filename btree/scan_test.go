@@ -0,0 +1,105 @@
+package btree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBTree_MinMax(t *testing.T) {
+	assert := assert.New(t)
+
+	btree := New[int, string]()
+	_, _, ok := btree.Min()
+	assert.False(ok)
+	_, _, ok = btree.Max()
+	assert.False(ok)
+
+	for _, k := range rand.Perm(100) {
+		btree.Put(k, fmt.Sprint(k))
+	}
+	key, val, ok := btree.Min()
+	assert.True(ok)
+	assert.Equal(0, key)
+	assert.Equal("0", val)
+	key, val, ok = btree.Max()
+	assert.True(ok)
+	assert.Equal(99, key)
+	assert.Equal("99", val)
+}
+
+func TestBTree_Scan(t *testing.T) {
+	assert := assert.New(t)
+
+	btree := NewWithOrder[int, int](6)
+	for _, k := range rand.Perm(200) {
+		btree.Put(k*5, k)
+	}
+
+	collect := func(scan func(fn func(key, val int) bool), limit int) []int {
+		keys := []int{}
+		scan(func(key, val int) bool {
+			keys = append(keys, key)
+			return len(keys) < limit
+		})
+		return keys
+	}
+
+	keys := collect(btree.Ascend, 1000)
+	assert.Len(keys, 200)
+	assert.Equal([]int{0, 5, 10}, collect(btree.Ascend, 3))
+	assert.Equal([]int{995, 990, 985}, collect(btree.Descend, 3))
+
+	ascendGE := func(pivot int) func(fn func(key, val int) bool) {
+		return func(fn func(key, val int) bool) { btree.AscendGreaterOrEqual(pivot, fn) }
+	}
+	assert.Equal([]int{100, 105}, collect(ascendGE(100), 2))
+	assert.Equal([]int{105, 110}, collect(ascendGE(101), 2))
+	assert.Empty(collect(ascendGE(1000), 2))
+
+	ascendRange := func(ge, lt int) func(fn func(key, val int) bool) {
+		return func(fn func(key, val int) bool) { btree.AscendRange(ge, lt, fn) }
+	}
+	assert.Equal([]int{100, 105, 110, 115}, collect(ascendRange(100, 120), 100))
+	assert.Equal([]int{105, 110, 115, 120}, collect(ascendRange(101, 121), 100))
+	assert.Equal([]int{100, 105}, collect(ascendRange(100, 120), 2))
+	assert.Empty(collect(ascendRange(120, 100), 100))
+	assert.Equal([]int{0, 5}, collect(ascendRange(-100, 10), 100))
+
+	descendRange := func(le, gt int) func(fn func(key, val int) bool) {
+		return func(fn func(key, val int) bool) { btree.DescendRange(le, gt, fn) }
+	}
+	assert.Equal([]int{120, 115, 110, 105}, collect(descendRange(120, 100), 100))
+	assert.Equal([]int{120, 115, 110, 105, 100}, collect(descendRange(124, 99), 100))
+	assert.Equal([]int{120, 115}, collect(descendRange(120, 100), 2))
+	assert.Equal([]int{995, 990}, collect(descendRange(2000, 985), 100))
+	assert.Empty(collect(descendRange(100, 120), 100))
+	assert.Empty(collect(descendRange(-1, -10), 100))
+}
+
+func Example_scan() {
+	btree := New[int, string]()
+	for i := 0; i < 10; i++ {
+		btree.Put(i, fmt.Sprint("v", i))
+	}
+
+	vals := []string{}
+	btree.AscendRange(3, 6, func(key int, val string) bool {
+		vals = append(vals, val)
+		return true
+	})
+	fmt.Println(vals)
+
+	keys := []int{}
+	btree.DescendRange(8, 0, func(key int, val string) bool {
+		keys = append(keys, key)
+		return len(keys) < 3
+	})
+	fmt.Println(keys)
+
+	// Output:
+	// [v3 v4 v5]
+	// [8 7 6]
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/multierr"
+	"golang.org/x/exp/constraints"
+)
+
+// Number defines the value types supported by the Increment and Decrement functions.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// GetOrSet returns the existing item under the provided key, if it exists and is not expired,
+// otherwise it inserts the new value. The lookup and the insertion are executed atomically.
+// The returned boolean is true if the item has been found in the cache and false if it has been stored.
+func (c *Cache[T, V]) GetOrSet(key T, val V, d time.Duration, opts ...ItemOption) (*Item[V], bool, error) {
+	if c.closed.Load() {
+		return nil, false, ErrorClosed
+	}
+
+	item, loaded, err := c.getOrSet(key, val, d, opts...)
+	c.record(loaded)
+
+	return item, loaded, err
+}
+
+// getOrSet is the internal version of GetOrSet, which does not update the hit and miss statistics.
+func (c *cache[T, V]) getOrSet(key T, val V, d time.Duration, opts ...ItemOption) (*Item[V], bool, error) {
+	if c.closed.Load() {
+		return nil, false, ErrorClosed
+	}
+
+	unlock := c.lockKey(key)
+	c.mu.Lock()
+	if item, ok := c.items[key]; ok && !item.expired(time.Now().UnixNano()) {
+		if c.policy != nil {
+			c.pmu.Lock()
+			c.policy.access(key)
+			c.pmu.Unlock()
+		}
+		c.mu.Unlock()
+		unlock()
+		return item, true, nil
+	}
+
+	item, err := c.newItem(key, val, d, opts...)
+	if err != nil {
+		c.mu.Unlock()
+		unlock()
+		return nil, false, err
+	}
+	evicted := c.insert(key, item)
+	c.mu.Unlock()
+	err = c.write(key, val)
+	unlock()
+
+	return item, false, multierr.Combine(err, c.notify(evicted))
+}
+
+// GetOrLoad returns the existing item under the provided key, if it exists and is not expired,
+// otherwise it invokes the loader function and stores its result with the default expiration time.
+// Concurrent calls for the same key are deduplicated, which means that only one loader function
+// execution is in flight for a given key at a time, the other callers waiting for its result.
+// The result of a failed loader function is not stored.
+func (c *Cache[T, V]) GetOrLoad(key T, loader func() (V, error), opts ...ItemOption) (*Item[V], error) {
+	if c.closed.Load() {
+		return nil, ErrorClosed
+	}
+
+	if item, err := c.Get(key); err == nil {
+		return item, nil
+	}
+
+	return c.group.Do(key, func() (*Item[V], error) {
+		if item, err := c.get(key); err == nil {
+			return item, nil
+		}
+		val, err := loader()
+		if err != nil {
+			return nil, err
+		}
+		item, _, err := c.getOrSet(key, val, DefaultExpiration, opts...)
+		return item, err
+	})
+}
+
+// Increment increases the numeric value of the item stored under the provided key by n
+// and returns the updated value. The item keeps its expiration time.
+// It returns an error if the item does not exist or it's expired.
+func Increment[T comparable, V Number](c *Cache[T, V], key T, n V) (V, error) {
+	if c.closed.Load() {
+		var v V
+		return v, ErrorClosed
+	}
+
+	unlock := c.lockKey(key)
+	defer unlock()
+
+	c.mu.Lock()
+	item, ok := c.items[key]
+	if !ok || item.expired(time.Now().UnixNano()) {
+		c.mu.Unlock()
+		var v V
+		return v, fmt.Errorf("item with key '%v' not found", key)
+	}
+	// The item is replaced instead of being modified in place,
+	// because the pointer to the old item could have been returned to another goroutine.
+	it := *item
+	it.object += n
+	c.items[key] = &it
+	c.mu.Unlock()
+
+	return it.object, c.write(key, it.object)
+}
+
+// Decrement decreases the numeric value of the item stored under the provided key by n
+// and returns the updated value. The item keeps its expiration time.
+// It returns an error if the item does not exist or it's expired.
+func Decrement[T comparable, V Number](c *Cache[T, V], key T, n V) (V, error) {
+	return Increment(c, key, -n)
+}
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_GetOrSet(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New[string, string](DefaultExpiration, time.Minute)
+	item, loaded, err := c.GetOrSet("foo", "bar", DefaultExpiration)
+	assert.NoError(err)
+	assert.False(loaded)
+	assert.Equal("bar", item.Val())
+
+	item, loaded, err = c.GetOrSet("foo", "baz", DefaultExpiration)
+	assert.NoError(err)
+	assert.True(loaded)
+	assert.Equal("bar", item.Val())
+
+	_, _, err = c.GetOrSet("baz", "", DefaultExpiration)
+	assert.Error(err)
+
+	c.Set("qux", "a", time.Millisecond)
+	<-time.After(5 * time.Millisecond)
+	item, loaded, err = c.GetOrSet("qux", "b", DefaultExpiration)
+	assert.NoError(err)
+	assert.False(loaded)
+	assert.Equal("b", item.Val())
+	assert.Equal(2, c.Count())
+}
+
+func TestCache_GetOrLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int32
+	c := New[string, int](DefaultExpiration, time.Minute)
+	loader := func() (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-time.After(20 * time.Millisecond)
+		return 10, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item, err := c.GetOrLoad("foo", loader)
+			assert.NoError(err)
+			assert.Equal(10, item.Val())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+
+	item, err := c.GetOrLoad("foo", loader)
+	assert.NoError(err)
+	assert.Equal(10, item.Val())
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+
+	item, err = c.GetOrLoad("bar", func() (int, error) {
+		return 0, fmt.Errorf("loader error")
+	})
+	assert.Error(err)
+	assert.Nil(item)
+	_, err = c.Get("bar")
+	assert.Error(err)
+}
+
+func TestCache_IncrementDecrement(t *testing.T) {
+	assert := assert.New(t)
+
+	c1 := New[string, int](DefaultExpiration, time.Minute)
+	_, err := Increment(c1, "foo", 1)
+	assert.Error(err)
+
+	c1.Set("foo", 1, time.Minute)
+	orig, _ := c1.Get("foo")
+	val, err := Increment(c1, "foo", 2)
+	assert.NoError(err)
+	assert.Equal(3, val)
+	val, err = Decrement(c1, "foo", 5)
+	assert.NoError(err)
+	assert.Equal(-2, val)
+
+	item, _ := c1.Get("foo")
+	assert.Equal(-2, item.Val())
+	assert.Equal(orig.expiration, item.expiration)
+	assert.Equal(1, orig.Val())
+
+	c2 := New[string, float64](DefaultExpiration, time.Minute)
+	c2.Set("foo", 1.5, DefaultExpiration)
+	fval, err := Increment(c2, "foo", 0.5)
+	assert.NoError(err)
+	assert.Equal(2.0, fval)
+
+	c3 := New[string, uint](DefaultExpiration, time.Minute)
+	c3.Set("foo", 5, DefaultExpiration)
+	uval, err := Decrement(c3, "foo", 2)
+	assert.NoError(err)
+	assert.Equal(uint(3), uval)
+
+	c3.Set("bar", 1, time.Millisecond)
+	<-time.After(5 * time.Millisecond)
+	_, err = Decrement(c3, "bar", 1)
+	assert.Error(err)
+
+	var wg sync.WaitGroup
+	c1.Update("foo", 0, DefaultExpiration)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Increment(c1, "foo", 1)
+		}()
+	}
+	wg.Wait()
+	item, _ = c1.Get("foo")
+	assert.Equal(100, item.Val())
+}
+
+func Example_getOrLoad() {
+	c := New[string, string](DefaultExpiration, time.Minute)
+	item, _ := c.GetOrLoad("foo", func() (string, error) {
+		return "bar", nil
+	})
+	fmt.Println(item.Val())
+
+	item, _ = c.GetOrLoad("foo", func() (string, error) {
+		return "baz", nil
+	})
+	fmt.Println(item.Val())
+
+	// Output:
+	// bar
+	// bar
+}
@@ -2,12 +2,18 @@
 // The cache and the cache items also have an expiration time. The cache will be invalidated
 // once the expiration time is reached. On cache initialization a cleanup interval is also required.
 // The scope of the cleanup method is to run at a predefined interval and to remove all the expired cache items.
+//
+// The cache can also be bounded by a maximum number of items and/or a maximum cost.
+// Once a limit is reached, the items are evicted according to the eviction policy (LRU, LFU or FIFO)
+// selected on cache initialization.
 package cache
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/multierr"
@@ -18,35 +24,194 @@ const (
 	DefaultExpiration time.Duration = 0
 )
 
+// ErrorClosed is returned by the cache operations invoked after the cache has been closed.
+var ErrorClosed = fmt.Errorf("cache is closed")
+
 // Item holds the cache object (which could be of any type) and an expiration time.
-// The expiration time defines the object lifetime.
+// The expiration time defines the object lifetime. In case of sliding expiration
+// the lifetime is extended with the item's original ttl each time the item is accessed.
 type Item[V any] struct {
 	object     V
 	expiration int64
+	cost       int64
+	ttl        time.Duration
+	deadline   int64
+	sliding    bool
+	tags       []string
 }
 
-type cache[T ~string, V any] struct {
+type cache[T comparable, V any] struct {
 	mu         sync.RWMutex
+	pmu        sync.Mutex // guards the eviction policy on concurrent reads
 	items      map[T]*Item[V]
 	done       chan struct{}
 	expTime    time.Duration
 	cleanupInt time.Duration
+	opts       options
+	policy     evictor[T]
+	cost       int64
+	onEvicted  func(T, V, EvictionReason) error
+	group      Group[T, *Item[V]]
+	stats      counters
+	closed     atomic.Bool
+	stop       sync.Once
+	tags       map[string]map[T]struct{}
+	prefixes   *prefixIndex[T]
+	store      Store[T, V]
+	pending    map[T]storeOp[V]
+	inflight   map[T]storeOp[V] // the write-behind operations being flushed
+	keys       keyLock[T]
+	wmu        sync.Mutex // guards the pending and in-flight write-behind operations
+	fmu        sync.Mutex // serializes the write-behind flushes
+	flushCh    chan struct{}
+}
+
+// EvictionReason describes the reason for which an item has been removed from the cache.
+type EvictionReason int
+
+const (
+	// Expired means that the item has been removed because its expiration time has been reached.
+	Expired EvictionReason = iota
+	// Deleted means that the item has been removed manually.
+	Deleted
+	// Capacity means that the item has been evicted to make room for a new item.
+	Capacity
+	// Flushed means that the item has been removed on cache flush.
+	Flushed
+)
+
+// String returns the description of the eviction reason.
+func (r EvictionReason) String() string {
+	switch r {
+	case Expired:
+		return "expired"
+	case Deleted:
+		return "deleted"
+	case Capacity:
+		return "capacity"
+	case Flushed:
+		return "flushed"
+	}
+	return "unknown"
+}
+
+// evictedItem holds an item removed from the cache, which is passed to the eviction callback.
+type evictedItem[T comparable, V any] struct {
+	key    T
+	val    V
+	reason EvictionReason
+}
+
+// Option is used to customize the cache on initialization.
+type Option func(*options)
+
+type options struct {
+	capacity int
+	maxCost  int64
+	policy   EvictionPolicy
+	metrics  MetricsHook
+	ctx      context.Context
+	hasher   any
+
+	writeBehind   bool
+	flushInterval time.Duration
+	batchSize     int
+}
+
+// WithCapacity limits the number of items stored in the cache.
+// Once the limit is reached, a new item is added only after evicting an existing one.
+func WithCapacity(n int) Option {
+	return func(o *options) {
+		o.capacity = n
+	}
+}
+
+// WithMaxCost limits the total cost of the items stored in the cache.
+// The cost of an item can be defined with the WithCost option, otherwise it defaults to 1.
+func WithMaxCost(n int64) Option {
+	return func(o *options) {
+		o.maxCost = n
+	}
+}
+
+// WithContext binds the cleanup goroutine to the context, which means that the expired items
+// are not removed anymore by the cleanup goroutine once the context is canceled.
+// The cache remains usable, but the expired items should be removed with DeleteExpired.
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// WithHasher defines the hash function used by the sharded cache for selecting the shard of a key,
+// replacing the default reflection based hashing of the struct and array keys with a faster one.
+// Equal keys must have the same hash. The option has no effect on a non sharded cache.
+func WithHasher[T comparable](fn func(T) uint64) Option {
+	return func(o *options) {
+		o.hasher = fn
+	}
+}
+
+// WithPolicy defines the eviction policy used by a bounded cache. The default policy is LRU.
+func WithPolicy(p EvictionPolicy) Option {
+	return func(o *options) {
+		o.policy = p
+	}
+}
+
+// ItemOption is used to customize a cache item on insertion.
+type ItemOption func(*itemOptions)
+
+type itemOptions struct {
+	cost    int64
+	sliding bool
+	maxAge  time.Duration
+	tags    []string
+}
+
+// WithCost defines the cost of the item, used for limiting the cache size with WithMaxCost.
+func WithCost(n int64) ItemOption {
+	return func(o *itemOptions) {
+		o.cost = n
+	}
+}
+
+// WithSliding enables the sliding expiration of the item, which means that each Get or Touch call
+// extends the item lifetime with its original expiration duration. If maxAge is greater than zero,
+// the item expires once the maxAge is reached since its insertion, regardless of how often it has been accessed.
+// It has no effect on the items which never expire.
+func WithSliding(maxAge time.Duration) ItemOption {
+	return func(o *itemOptions) {
+		o.sliding = true
+		o.maxAge = maxAge
+	}
 }
 
 // Cache is a publicly available struct type, which incorporates the
 // unexported cache struct type holding the cache components.
-type Cache[T ~string, V any] struct {
+type Cache[T comparable, V any] struct {
 	*cache[T, V]
 }
 
 // newCache has a local scope only. `New` will be used for the cache instantiation outside this package.
-func newCache[T ~string, V any](expTime, cleanupInt time.Duration, item map[T]*Item[V]) *cache[T, V] {
+func newCache[T comparable, V any](expTime, cleanupInt time.Duration, item map[T]*Item[V], opts options) *cache[T, V] {
 	c := &cache[T, V]{
 		mu:         sync.RWMutex{},
 		items:      item,
 		expTime:    expTime,
 		cleanupInt: cleanupInt,
 		done:       make(chan struct{}),
+		opts:       opts,
+	}
+	if opts.capacity > 0 || opts.maxCost > 0 {
+		c.policy = newEvictor[T](opts.policy)
+	}
+	// The string based keys are indexed from the start, so that DeletePrefix doesn't need to scan the items.
+	if stringKeys[T]() {
+		c.prefixes = newPrefixIndex[T]()
+		for k := range item {
+			c.prefixes.add(keyString(k), k)
+		}
 	}
 	return c
 }
@@ -55,44 +220,117 @@ func newCache[T ~string, V any](expTime, cleanupInt time.Duration, item map[T]*I
 // The cache will be invalidated once the expiration time is reached.
 // If the expiration time is less than zero (or NoExpiration) the cache items will never expire and should be deleted manually.
 // A cleanup method is running in the background and removes the expired caches at a predefined interval.
-func New[T ~string, V any](expTime, cleanupTime time.Duration) *Cache[T, V] {
+//
+// The optional arguments can be used to bound the cache size, using WithCapacity and WithMaxCost,
+// and to select the eviction policy applied once the limit is reached, using WithPolicy.
+//
+// The cleanup goroutine should be stopped by calling Close once the cache is not needed anymore,
+// or by canceling the context provided with WithContext.
+func New[T comparable, V any](expTime, cleanupTime time.Duration, opts ...Option) *Cache[T, V] {
+	return build[T, V](nil, expTime, cleanupTime, opts...)
+}
+
+// build creates the cache and starts its background goroutines.
+func build[T comparable, V any](store Store[T, V], expTime, cleanupTime time.Duration, opts ...Option) *Cache[T, V] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	items := make(map[T]*Item[V])
-	c := newCache(expTime, cleanupTime, items)
+	c := newCache(expTime, cleanupTime, items, o)
+	c.store = store
+	cc := &Cache[T, V]{c}
 
 	if cleanupTime > 0 {
 		go c.cleanup()
-		// We need to make sure that the goroutine responsible for the cache eviction stops after the cleanup.
-		// This is the reason why runtime.SetFinalizer is used.
+	}
+	if store != nil && o.writeBehind {
+		c.pending = make(map[T]storeOp[V])
+		c.flushCh = make(chan struct{}, 1)
+		go c.writer()
+	}
+	if cleanupTime > 0 || c.pending != nil {
+		// As a fallback for the case when Close is not invoked, we need to make sure that the goroutines
+		// running in the background stop once the cache becomes unreachable.
+		// This is the reason why runtime.SetFinalizer is used. The finalizer is set on the exported wrapper,
+		// because the unexported cache is referenced by the background goroutines, so it never becomes unreachable.
 		// This method is invoked when the garbage collector finds an unreachable block ready to be collected.
-		runtime.SetFinalizer(c, stopCleanup[T, V])
+		runtime.SetFinalizer(cc, stopCleanup[T, V])
 	}
 
-	return &Cache[T, V]{c}
+	return cc
 }
 
 // Set inserts a new item into the cache, but first verifies if an item with the same key already exists in the cache.
 // In case an item with the specified key already exists in the cache it will return an error.
-func (c *Cache[T, V]) Set(key T, val V, d time.Duration) error {
-	item, err := c.Get(key)
-	if item != nil && err == nil {
+// If the cache is bounded and the limit has been reached, an existing item is evicted
+// according to the eviction policy.
+func (c *Cache[T, V]) Set(key T, val V, d time.Duration, opts ...ItemOption) error {
+	if c.closed.Load() {
+		return ErrorClosed
+	}
+
+	if c.exists(key) {
 		return fmt.Errorf("item with key '%v' already exists. Use the Update method", key)
 	}
-	c.add(key, val, d)
 
-	return nil
+	return c.add(key, val, d, opts...)
+}
+
+// exists checks if a non expired item exists under the key. Unlike get, it doesn't count
+// as an access for the eviction policy and it doesn't extend the sliding expiration.
+func (c *cache[T, V]) exists(key T) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	item, ok := c.items[key]
+	return ok && !item.expired(time.Now().UnixNano())
 }
 
 // SetDefault adds a new item into the cache with the default expiration time.
-func (c *Cache[T, V]) SetDefault(key T, val V) error {
-	return c.Set(key, val, DefaultExpiration)
+func (c *Cache[T, V]) SetDefault(key T, val V, opts ...ItemOption) error {
+	return c.Set(key, val, DefaultExpiration, opts...)
 }
 
 // add inserts a new item into the cache together with an expiration time.
 // If the duration is 0 (or DefaultExpiration) the cache default expiration time is used.
 // If the duration is < 0 (or NoExpiration), the item never expires and should be removed manually.
-func (c *Cache[T, V]) add(key T, val V, d time.Duration) error {
+func (c *Cache[T, V]) add(key T, val V, d time.Duration, opts ...ItemOption) error {
+	item, err := c.newItem(key, val, d, opts...)
+	if err != nil {
+		return err
+	}
+
+	unlock := c.lockKey(key)
+	if err := c.write(key, val); err != nil {
+		unlock()
+		return err
+	}
+	c.mu.Lock()
+	evicted := c.insert(key, item)
+	c.mu.Unlock()
+	unlock()
+
+	return c.notify(evicted)
+}
+
+// newItem validates the value and creates a new cache item having the expiration time computed from the duration.
+func (c *cache[T, V]) newItem(key T, val V, d time.Duration, opts ...ItemOption) (*Item[V], error) {
 	var exp int64
 
+	if c.closed.Load() {
+		return nil, ErrorClosed
+	}
+
+	o := itemOptions{cost: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if c.opts.maxCost > 0 && o.cost > c.opts.maxCost {
+		return nil, fmt.Errorf("item with key '%v' exceeds the maximum cost of the cache", key)
+	}
+
 	if d == DefaultExpiration {
 		d = c.expTime
 	}
@@ -102,32 +340,151 @@ func (c *Cache[T, V]) add(key T, val V, d time.Duration) error {
 		exp = int64(NoExpiration)
 	}
 
-	item, err := c.Get(key)
-	if item != nil && err != nil {
-		return fmt.Errorf("item with key '%v' already exists", key)
-	}
-
 	switch any(val).(type) {
 	case string:
 		if len(any(val).(string)) == 0 {
-			return fmt.Errorf("value of type string cannot be empty")
+			return nil, fmt.Errorf("value of type string cannot be empty")
 		}
 	}
 
-	c.mu.Lock()
-	c.items[key] = &Item[V]{
+	item := &Item[V]{
 		object:     val,
 		expiration: exp,
+		cost:       o.cost,
+		tags:       o.tags,
+	}
+	if d > 0 {
+		item.ttl = d
+		item.sliding = o.sliding
+		if o.maxAge > 0 {
+			item.deadline = time.Now().Add(o.maxAge).UnixNano()
+			if item.expiration > item.deadline {
+				item.expiration = item.deadline
+			}
+		}
+	}
+
+	return item, nil
+}
+
+// insert stores the item under the provided key, evicting the existing items if the cache is bounded.
+// It returns the evicted items and it must be called with the write lock held.
+func (c *cache[T, V]) insert(key T, item *Item[V]) []evictedItem[T, V] {
+	// An existing item is replaced, so it is considered as a new entry by the eviction policy.
+	if _, ok := c.items[key]; ok {
+		c.delete(key)
+	}
+	evicted := c.evict(item.cost)
+
+	c.items[key] = item
+	c.cost += item.cost
+	if c.policy != nil {
+		c.pmu.Lock()
+		c.policy.add(key)
+		c.pmu.Unlock()
 	}
+	c.index(key, item)
+
+	return evicted
+}
+
+// evict removes items from a bounded cache, according to the eviction policy,
+// until there is enough room for a new item with the provided cost.
+// It must be called with the write lock held.
+func (c *cache[T, V]) evict(cost int64) []evictedItem[T, V] {
+	var items []evictedItem[T, V]
+
+	if c.policy == nil {
+		return items
+	}
+
+	for (c.opts.capacity > 0 && len(c.items) >= c.opts.capacity) ||
+		(c.opts.maxCost > 0 && c.cost+cost > c.opts.maxCost) {
+		c.pmu.Lock()
+		key, ok := c.policy.victim()
+		c.pmu.Unlock()
+		if !ok {
+			break
+		}
+		items = c.collect(items, key, Capacity)
+		c.delete(key)
+	}
+
+	return items
+}
+
+// OnEvicted sets an optional callback function which is invoked each time an item is removed from the cache,
+// either because it has expired, it has been deleted manually, it has been evicted to make room
+// for a new item or the cache has been flushed. The callback is invoked outside of the cache lock,
+// and the returned errors are reported by the method which triggered the eviction.
+// Passing nil disables the callback.
+func (c *Cache[T, V]) OnEvicted(fn func(key T, val V, reason EvictionReason) error) {
+	c.mu.Lock()
+	c.onEvicted = fn
 	c.mu.Unlock()
+}
+
+// collect appends the item under the provided key to the list of items passed to the eviction callback.
+// It must be called with the write lock held, before removing the item.
+func (c *cache[T, V]) collect(items []evictedItem[T, V], key T, reason EvictionReason) []evictedItem[T, V] {
+	item, ok := c.items[key]
+	if !ok {
+		return items
+	}
+	c.removed(reason)
 
-	return nil
+	if c.onEvicted == nil && c.opts.metrics == nil {
+		return items
+	}
+	return append(items, evictedItem[T, V]{key: key, val: item.object, reason: reason})
+}
+
+// notify invokes the eviction callback for each evicted item and combines the returned errors.
+// It must be called without holding the lock, so the callback is able to access the cache.
+func (c *cache[T, V]) notify(items []evictedItem[T, V]) error {
+	var err error
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	c.mu.RLock()
+	fn := c.onEvicted
+	c.mu.RUnlock()
+
+	for _, it := range items {
+		if c.opts.metrics != nil {
+			c.opts.metrics.OnEviction(it.reason)
+		}
+		if fn != nil {
+			err = multierr.Append(err, fn(it.key, it.val, it.reason))
+		}
+	}
+
+	return err
 }
 
 // Get returns a cache item defined by its key. If the item is expired an error is returned.
 // If an item is expired it's considered as nonexistent, it will be evicted from the cache
 // when the purge method is invoked at the predefined interval.
+// In case of sliding expiration, the item lifetime is extended on each call.
 func (c *Cache[T, V]) Get(key T) (*Item[V], error) {
+	item, err := c.get(key)
+	c.record(err == nil)
+
+	if c.store != nil && isMiss(err) {
+		return c.readThrough(key)
+	}
+
+	return item, err
+}
+
+// get is the internal version of Get, which does not update the hit and miss statistics.
+func (c *cache[T, V]) get(key T) (*Item[V], error) {
+	if c.closed.Load() {
+		return nil, ErrorClosed
+	}
+
 	c.mu.RLock()
 	if item, ok := c.items[key]; ok {
 		if item.expiration > 0 {
@@ -137,6 +494,16 @@ func (c *Cache[T, V]) Get(key T) (*Item[V], error) {
 				return nil, fmt.Errorf("item with key '%v' expired", key)
 			}
 		}
+		if item.sliding {
+			// The expiration time is modified, so the write lock is required.
+			c.mu.RUnlock()
+			return c.touch(key)
+		}
+		if c.policy != nil {
+			c.pmu.Lock()
+			c.policy.access(key)
+			c.pmu.Unlock()
+		}
 		c.mu.RUnlock()
 		return item, nil
 	}
@@ -144,6 +511,75 @@ func (c *Cache[T, V]) Get(key T) (*Item[V], error) {
 	return nil, fmt.Errorf("item with key '%v' not found", key)
 }
 
+// GetStale returns a cache item defined by its key even if it's expired, as long as it has not been removed yet
+// by the cleanup method. The returned boolean reports whether the item is expired. Unlike Get, it doesn't update
+// the cache statistics, it doesn't load the missing items from the backing store and it doesn't extend
+// the lifetime of the items with sliding expiration.
+func (c *Cache[T, V]) GetStale(key T) (*Item[V], bool, error) {
+	if c.closed.Load() {
+		return nil, false, ErrorClosed
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if item, ok := c.items[key]; ok {
+		return item, item.expired(time.Now().UnixNano()), nil
+	}
+	return nil, false, fmt.Errorf("item with key '%v' not found", key)
+}
+
+// Touch extends the lifetime of an item with its original expiration duration, without exceeding
+// the maximum age defined with WithSliding. It returns an error if the item does not exist or it's expired.
+func (c *Cache[T, V]) Touch(key T) error {
+	_, err := c.touch(key)
+	return err
+}
+
+// touch refreshes the expiration time of the item under the write lock and returns the item.
+func (c *cache[T, V]) touch(key T) (*Item[V], error) {
+	if c.closed.Load() {
+		return nil, ErrorClosed
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.items[key]
+	if !ok {
+		return nil, fmt.Errorf("item with key '%v' not found", key)
+	}
+	now := time.Now().UnixNano()
+	if item.expired(now) {
+		return nil, fmt.Errorf("item with key '%v' expired", key)
+	}
+	item.refresh(now)
+	if c.policy != nil {
+		c.pmu.Lock()
+		c.policy.access(key)
+		c.pmu.Unlock()
+	}
+
+	return item, nil
+}
+
+// refresh extends the expiration time of the item with its ttl, without exceeding its deadline.
+func (it *Item[V]) refresh(now int64) {
+	if it.ttl <= 0 {
+		return
+	}
+	exp := now + int64(it.ttl)
+	if it.deadline > 0 && exp > it.deadline {
+		exp = it.deadline
+	}
+	it.expiration = exp
+}
+
+// expired checks if the item's expiration time has been reached.
+func (it *Item[V]) expired(now int64) bool {
+	return it.expiration > 0 && now > it.expiration
+}
+
 // Val returns the effective value of the cache item.
 func (it *Item[V]) Val() V {
 	var v V
@@ -154,26 +590,48 @@ func (it *Item[V]) Val() V {
 }
 
 // Update replaces a cache item with the new value.
-func (c *Cache[T, V]) Update(key T, val V, d time.Duration) error {
-	item, err := c.Get(key)
-	if item != nil && err != nil {
-		return err
+func (c *Cache[T, V]) Update(key T, val V, d time.Duration, opts ...ItemOption) error {
+	if c.closed.Load() {
+		return ErrorClosed
 	}
-	return c.add(key, val, d)
+
+	return c.add(key, val, d, opts...)
 }
 
-// Delete removes a cache item.
+// Delete removes a cache item. If the cache is backed by a store, the item is removed from the store too.
 func (c *Cache[T, V]) Delete(key T) error {
+	if c.closed.Load() {
+		return ErrorClosed
+	}
+
+	unlock := c.lockKey(key)
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	evicted := c.collect(nil, key, Deleted)
+	if err := c.delete(key); err != nil && c.store == nil {
+		c.mu.Unlock()
+		unlock()
+		return err
+	}
+	c.mu.Unlock()
+
+	// The item could exist in the backing store even if it's not cached.
+	err := c.erase(key)
+	unlock()
 
-	return c.delete(key)
+	return multierr.Combine(err, c.notify(evicted))
 }
 
 // delete has a local scope only.
 func (c *cache[T, V]) delete(key T) error {
-	if _, ok := c.items[key]; ok {
+	if item, ok := c.items[key]; ok {
 		delete(c.items, key)
+		c.cost -= item.cost
+		if c.policy != nil {
+			c.pmu.Lock()
+			c.policy.remove(key)
+			c.pmu.Unlock()
+		}
+		c.unindex(key, item)
 
 		return nil
 	}
@@ -182,8 +640,16 @@ func (c *cache[T, V]) delete(key T) error {
 }
 
 // DeleteExpired removes all the expired items from the cache.
+// The returned error combines the errors of the eviction callback invoked for each expired item.
 func (c *cache[T, V]) DeleteExpired() error {
-	var err error
+	var (
+		err     error
+		evicted []evictedItem[T, V]
+	)
+
+	if c.closed.Load() {
+		return ErrorClosed
+	}
 
 	now := time.Now().UnixNano()
 
@@ -192,6 +658,7 @@ func (c *cache[T, V]) DeleteExpired() error {
 	// will be accepted and integrated into the standard library.
 	for k, item := range c.items {
 		if now > item.expiration && item.expiration != int64(NoExpiration) {
+			evicted = c.collect(evicted, k, Expired)
 			if e := c.delete(k); e != nil {
 				err = multierr.Append(err, e)
 			}
@@ -200,14 +667,34 @@ func (c *cache[T, V]) DeleteExpired() error {
 	}
 	c.mu.Unlock()
 
+	err = multierr.Append(err, c.notify(evicted))
+
 	return multierr.Combine(err)
 }
 
 // Flush removes all the existing items in the cache.
+// The errors returned by the eviction callback are discarded.
 func (c *Cache[T, V]) Flush() {
+	var evicted []evictedItem[T, V]
+
 	c.mu.Lock()
+	for k := range c.items {
+		evicted = c.collect(evicted, k, Flushed)
+	}
 	c.items = make(map[T]*Item[V])
+	c.cost = 0
+	if c.policy != nil {
+		c.pmu.Lock()
+		c.policy.reset()
+		c.pmu.Unlock()
+	}
+	c.tags = nil
+	if c.prefixes != nil {
+		c.prefixes = newPrefixIndex[T]()
+	}
 	c.mu.Unlock()
+
+	c.notify(evicted)
 }
 
 // List returns the cache items which are not expired.
@@ -240,32 +727,61 @@ func (c *Cache[T, V]) MapToCache(m map[T]V, d time.Duration) error {
 }
 
 // IsExpired checks if a cache item is expired.
+// It does not extend the lifetime of the items with sliding expiration.
 func (c *Cache[T, V]) IsExpired(key T) bool {
-	item, err := c.Get(key)
-	if item != nil && err != nil {
-		if item.expiration > time.Now().UnixNano() {
-			return true
-		}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if item, ok := c.items[key]; ok {
+		return item.expired(time.Now().UnixNano())
 	}
 	return false
 }
 
+// Close stops the cleanup goroutine and releases the cache. After the cache has been closed,
+// the operations which are returning an error will fail with ErrorClosed.
+// Closing an already closed cache returns ErrorClosed.
+func (c *Cache[T, V]) Close() error {
+	if !c.closed.CompareAndSwap(false, true) {
+		return ErrorClosed
+	}
+	c.stopCleanup()
+
+	// Flush the write-behind operations which are still pending.
+	return c.Sync()
+}
+
 // cleanup runs the cache cleanup function at the specified time interval an removes all the expired cache items.
+// It stops when the cache is closed or the context provided on cache initialization is canceled.
 func (c *cache[T, V]) cleanup() {
+	var ctxDone <-chan struct{}
+	if c.opts.ctx != nil {
+		ctxDone = c.opts.ctx.Done()
+	}
+
 	tick := time.NewTicker(c.cleanupInt)
+	defer tick.Stop()
 
 	for {
 		select {
 		case <-tick.C:
 			c.DeleteExpired()
 		case <-c.done:
-			tick.Stop()
+			return
+		case <-ctxDone:
 			return
 		}
 	}
 }
 
+// stopCleanup signals the cleanup goroutine to stop. It's safe to be called multiple times.
+func (c *cache[T, V]) stopCleanup() {
+	c.stop.Do(func() {
+		close(c.done)
+	})
+}
+
 // stopCleanup stops the cleanup process once the cache item goes out of scope and became unreachable.
-func stopCleanup[T ~string, V any](c *cache[T, V]) {
-	c.done <- struct{}{}
+func stopCleanup[T comparable, V any](c *Cache[T, V]) {
+	c.stopCleanup()
 }
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -300,3 +301,235 @@ func Example_expirationTime() {
 	// 2
 	// 1
 }
+
+func TestCache_OnEvicted(t *testing.T) {
+	assert := assert.New(t)
+
+	evicted := make(map[string]EvictionReason)
+	c := New[string, int](NoExpiration, 0, WithCapacity(2))
+	c.OnEvicted(func(key string, val int, reason EvictionReason) error {
+		evicted[key] = reason
+		// The callback is invoked outside of the lock, so the cache can be accessed.
+		c.Count()
+		if val < 0 {
+			return fmt.Errorf("negative value for key '%v'", key)
+		}
+		return nil
+	})
+
+	c.Set("a", 1, DefaultExpiration)
+	c.Set("b", 2, DefaultExpiration)
+	c.Set("c", 3, DefaultExpiration)
+	assert.Equal(Capacity, evicted["a"])
+
+	err := c.Delete("b")
+	assert.NoError(err)
+	assert.Equal(Deleted, evicted["b"])
+
+	err = c.Delete("b")
+	assert.Error(err)
+
+	c.Set("d", -4, 1*time.Millisecond)
+	<-time.After(5 * time.Millisecond)
+	err = c.DeleteExpired()
+	assert.Error(err)
+	assert.Equal(Expired, evicted["d"])
+
+	c.Set("e", 5, DefaultExpiration)
+	c.Flush()
+	assert.Equal(Flushed, evicted["c"])
+	assert.Equal(Flushed, evicted["e"])
+	assert.Len(evicted, 5)
+
+	c.OnEvicted(nil)
+	c.Set("f", 6, DefaultExpiration)
+	c.Delete("f")
+	assert.Len(evicted, 5)
+
+	assert.Equal("expired", Expired.String())
+	assert.Equal("deleted", Deleted.String())
+	assert.Equal("capacity", Capacity.String())
+	assert.Equal("flushed", Flushed.String())
+	assert.Equal("unknown", EvictionReason(10).String())
+}
+
+func TestCache_SlidingExpiration(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New[string, string](DefaultExpiration, 0)
+	c.Set("foo", "bar", 30*time.Millisecond, WithSliding(0))
+	c.Set("baz", "qux", 30*time.Millisecond)
+
+	for i := 0; i < 4; i++ {
+		<-time.After(15 * time.Millisecond)
+		item, err := c.Get("foo")
+		assert.NoError(err)
+		assert.Equal("bar", item.Val())
+	}
+	assert.False(c.IsExpired("foo"))
+	assert.True(c.IsExpired("baz"))
+	_, err := c.Get("baz")
+	assert.Error(err)
+
+	// IsExpired does not extend the item lifetime.
+	<-time.After(10 * time.Millisecond)
+	assert.False(c.IsExpired("foo"))
+	<-time.After(30 * time.Millisecond)
+	assert.True(c.IsExpired("foo"))
+	_, err = c.Get("foo")
+	assert.Error(err)
+	err = c.Touch("foo")
+	assert.Error(err)
+	err = c.Touch("missing")
+	assert.Error(err)
+
+	// The lifetime of the item can't be extended beyond its max age.
+	c.Update("foo", "bar", 20*time.Millisecond, WithSliding(50*time.Millisecond))
+	for i := 0; i < 3; i++ {
+		<-time.After(10 * time.Millisecond)
+		err = c.Touch("foo")
+		assert.NoError(err)
+	}
+	<-time.After(30 * time.Millisecond)
+	_, err = c.Get("foo")
+	assert.Error(err)
+	c.DeleteExpired()
+	assert.Equal(0, c.Count())
+
+	// Touch extends the lifetime of the items without sliding expiration too.
+	c.Set("a", "b", 20*time.Millisecond)
+	<-time.After(15 * time.Millisecond)
+	err = c.Touch("a")
+	assert.NoError(err)
+	<-time.After(15 * time.Millisecond)
+	assert.False(c.IsExpired("a"))
+
+	// The items which never expire are not affected.
+	c.Set("c", "d", NoExpiration, WithSliding(time.Millisecond))
+	<-time.After(5 * time.Millisecond)
+	item, err := c.Get("c")
+	assert.NoError(err)
+	assert.Equal(int64(NoExpiration), item.expiration)
+}
+
+func TestCache_ComparableKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	type key struct {
+		tenant string
+		id     int
+	}
+
+	c1 := New[int, string](DefaultExpiration, time.Minute, WithCapacity(2))
+	c1.Set(1, "a", DefaultExpiration)
+	c1.Set(2, "b", DefaultExpiration)
+	c1.Set(3, "c", DefaultExpiration)
+	_, err := c1.Get(1)
+	assert.Error(err)
+	item, err := c1.GetOrLoad(4, func() (string, error) {
+		return "d", nil
+	})
+	assert.NoError(err)
+	assert.Equal("d", item.Val())
+
+	c2 := New[key, int](DefaultExpiration, time.Minute)
+	c2.Set(key{"foo", 1}, 1, DefaultExpiration)
+	c2.Set(key{"foo", 2}, 2, DefaultExpiration)
+	item2, err := c2.Get(key{"foo", 2})
+	assert.NoError(err)
+	assert.Equal(2, item2.Val())
+	val, err := Increment(c2, key{"foo", 1}, 2)
+	assert.NoError(err)
+	assert.Equal(3, val)
+	err = c2.Set(key{"foo", 1}, 1, DefaultExpiration)
+	assert.EqualError(err, "item with key '{foo 1}' already exists. Use the Update method")
+}
+
+func TestCache_Close(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New[string, int](DefaultExpiration, 5*time.Millisecond)
+	c.Set("a", 1, 10*time.Millisecond)
+	c.Set("b", 2, NoExpiration)
+	err := c.Close()
+	assert.NoError(err)
+	err = c.Close()
+	assert.ErrorIs(err, ErrorClosed)
+
+	// The cleanup goroutine is stopped, so the expired items are not removed anymore.
+	<-time.After(30 * time.Millisecond)
+	assert.Equal(2, c.Count())
+
+	_, err = c.Get("b")
+	assert.ErrorIs(err, ErrorClosed)
+	assert.ErrorIs(c.Set("c", 3, DefaultExpiration), ErrorClosed)
+	assert.ErrorIs(c.SetDefault("c", 3), ErrorClosed)
+	assert.ErrorIs(c.Update("b", 3, DefaultExpiration), ErrorClosed)
+	assert.ErrorIs(c.Delete("b"), ErrorClosed)
+	assert.ErrorIs(c.Touch("b"), ErrorClosed)
+	assert.ErrorIs(c.DeleteExpired(), ErrorClosed)
+	_, _, err = c.GetOrSet("c", 3, DefaultExpiration)
+	assert.ErrorIs(err, ErrorClosed)
+	item, loaded, err := c.GetOrSet("b", 3, DefaultExpiration)
+	assert.ErrorIs(err, ErrorClosed)
+	assert.False(loaded)
+	assert.Nil(item)
+	_, err = c.GetOrLoad("c", func() (int, error) {
+		return 3, nil
+	})
+	assert.ErrorIs(err, ErrorClosed)
+	_, err = Increment(c, "b", 1)
+	assert.ErrorIs(err, ErrorClosed)
+	assert.Equal(2, c.Count())
+
+	c = New[string, int](DefaultExpiration, 0)
+	assert.NoError(c.Close())
+}
+
+func TestCache_CleanupContext(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := New[string, int](DefaultExpiration, 5*time.Millisecond, WithContext(ctx))
+	c.Set("a", 1, time.Millisecond)
+	<-time.After(30 * time.Millisecond)
+	assert.Equal(0, c.Count())
+
+	cancel()
+	<-time.After(10 * time.Millisecond)
+	c.Set("a", 1, time.Millisecond)
+	<-time.After(30 * time.Millisecond)
+	assert.Equal(1, c.Count())
+
+	// The cache remains usable after the cancellation.
+	assert.NoError(c.DeleteExpired())
+	assert.Equal(0, c.Count())
+	assert.NoError(c.Close())
+}
+
+func TestCache_GetStale(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New[string, int](DefaultExpiration, 0)
+	c.Set("a", 1, 5*time.Millisecond)
+	item, expired, err := c.GetStale("a")
+	assert.NoError(err)
+	assert.False(expired)
+	assert.Equal(1, item.Val())
+
+	<-time.After(10 * time.Millisecond)
+	item, expired, err = c.GetStale("a")
+	assert.NoError(err)
+	assert.True(expired)
+	assert.Equal(1, item.Val())
+	_, err = c.Get("a")
+	assert.Error(err)
+
+	c.DeleteExpired()
+	_, _, err = c.GetStale("a")
+	assert.Error(err)
+
+	c.Close()
+	_, _, err = c.GetStale("a")
+	assert.ErrorIs(err, ErrorClosed)
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"sync"
+)
+
+// call represents an in-flight or completed function invocation.
+type call[R any] struct {
+	wg  sync.WaitGroup
+	res R
+	err error
+}
+
+// Group is a typed version of the singleflight group, which makes sure that only one
+// function execution is in flight for a given key at a time. Unlike singleflight.Group,
+// which supports only string keys, it accepts any comparable key type.
+// The zero value is ready to use. The cache uses it to deduplicate the loads of the same key.
+type Group[T comparable, R any] struct {
+	mu    sync.Mutex
+	calls map[T]*call[R]
+}
+
+// Do executes the function, making sure that only one execution is in flight for the key.
+// If a duplicate call comes in, the caller waits for the original call to complete and receives the same results.
+func (g *Group[T, R]) Do(key T, fn func() (R, error)) (R, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[T]*call[R])
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.res, c.err
+	}
+	c := new(call[R])
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+	c.res, c.err = fn()
+
+	return c.res, c.err
+}
+
+// keyLock is a set of mutexes indexed by key, which serializes the operations of the same key,
+// while the operations of different keys are running concurrently.
+type keyLock[T comparable] struct {
+	mu    sync.Mutex
+	locks map[T]*keyMutex
+}
+
+// keyMutex is the mutex of a single key, together with the number of goroutines holding or waiting for it.
+type keyMutex struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock locks the mutex of the key.
+func (l *keyLock[T]) lock(key T) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[T]*keyMutex)
+	}
+	m, ok := l.locks[key]
+	if !ok {
+		m = new(keyMutex)
+		l.locks[key] = m
+	}
+	m.refs++
+	l.mu.Unlock()
+
+	m.mu.Lock()
+}
+
+// unlock unlocks the mutex of the key, removing it once it's not used anymore.
+func (l *keyLock[T]) unlock(key T) {
+	l.mu.Lock()
+	m := l.locks[key]
+	m.refs--
+	if m.refs == 0 {
+		delete(l.locks, key)
+	}
+	l.mu.Unlock()
+
+	m.mu.Unlock()
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// prefixNode is a node of the prefix tree used for indexing the string based keys.
+// Each node corresponds to a byte of the key, the key itself being stored in the node of its last byte.
+type prefixNode[T comparable] struct {
+	children map[byte]*prefixNode[T]
+	key      T
+	ok       bool
+}
+
+// prefixIndex is a byte-wise prefix tree which makes possible to retrieve all the keys
+// starting with a prefix in a time proportional with the number of matching keys.
+type prefixIndex[T comparable] struct {
+	root *prefixNode[T]
+}
+
+func newPrefixIndex[T comparable]() *prefixIndex[T] {
+	return &prefixIndex[T]{root: &prefixNode[T]{}}
+}
+
+// add indexes the key under its string representation.
+func (p *prefixIndex[T]) add(s string, key T) {
+	n := p.root
+	for i := 0; i < len(s); i++ {
+		if n.children == nil {
+			n.children = make(map[byte]*prefixNode[T])
+		}
+		child, ok := n.children[s[i]]
+		if !ok {
+			child = &prefixNode[T]{}
+			n.children[s[i]] = child
+		}
+		n = child
+	}
+	n.key, n.ok = key, true
+}
+
+// remove drops the key from the index, pruning the nodes which are not needed anymore.
+func (p *prefixIndex[T]) remove(s string) {
+	path := make([]*prefixNode[T], 0, len(s)+1)
+	n := p.root
+	path = append(path, n)
+	for i := 0; i < len(s); i++ {
+		child, ok := n.children[s[i]]
+		if !ok {
+			return
+		}
+		n = child
+		path = append(path, n)
+	}
+
+	var key T
+	n.key, n.ok = key, false
+
+	for i := len(s); i > 0; i-- {
+		node := path[i]
+		if node.ok || len(node.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, s[i-1])
+	}
+}
+
+// collect returns all the keys starting with the prefix.
+func (p *prefixIndex[T]) collect(prefix string) []T {
+	var keys []T
+
+	n := p.root
+	for i := 0; i < len(prefix); i++ {
+		child, ok := n.children[prefix[i]]
+		if !ok {
+			return keys
+		}
+		n = child
+	}
+
+	stack := []*prefixNode[T]{n}
+	for len(stack) > 0 {
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n.ok {
+			keys = append(keys, n.key)
+		}
+		for _, child := range n.children {
+			stack = append(stack, child)
+		}
+	}
+
+	return keys
+}
+
+// stringKeys reports whether the underlying type of the key type is string.
+func stringKeys[T comparable]() bool {
+	return reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.String
+}
+
+// keyString converts the key to string without reflection. It must be called only if stringKeys reports true,
+// in which case the key has the same memory layout as a string.
+func keyString[T comparable](key T) string {
+	return *(*string)(unsafe.Pointer(&key))
+}
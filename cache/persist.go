@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// Encoding defines the format used for serializing the cache items.
+type Encoding int
+
+const (
+	// Gob encodes the cache items using the encoding/gob package.
+	// If the cache values are interfaces, the concrete types should be registered with gob.Register.
+	Gob Encoding = iota
+	// JSON encodes the cache items using the encoding/json package.
+	JSON
+)
+
+// snapshotItem is the serializable form of a cache item.
+// The expiration is stored as a timestamp, so the item lifetime is preserved between restarts.
+type snapshotItem[T comparable, V any] struct {
+	Key        T
+	Object     V
+	Expiration int64
+	Cost       int64
+	TTL        time.Duration
+	Deadline   int64
+	Sliding    bool
+	Tags       []string
+}
+
+// Save serializes the items which are not expired into the writer using the requested encoding.
+func (c *Cache[T, V]) Save(w io.Writer, enc Encoding) error {
+	if c.closed.Load() {
+		return ErrorClosed
+	}
+
+	now := time.Now().UnixNano()
+
+	c.mu.RLock()
+	items := make([]snapshotItem[T, V], 0, len(c.items))
+	for k, item := range c.items {
+		if item.expiration > 0 && now > item.expiration {
+			continue
+		}
+		items = append(items, snapshotItem[T, V]{
+			Key:        k,
+			Object:     item.object,
+			Expiration: item.expiration,
+			Cost:       item.cost,
+			TTL:        item.ttl,
+			Deadline:   item.deadline,
+			Sliding:    item.sliding,
+			Tags:       item.tags,
+		})
+	}
+	c.mu.RUnlock()
+
+	switch enc {
+	case Gob:
+		return gob.NewEncoder(w).Encode(items)
+	case JSON:
+		return json.NewEncoder(w).Encode(items)
+	}
+
+	return fmt.Errorf("unsupported encoding: %v", enc)
+}
+
+// Load restores the cache items from the reader, which should contain a snapshot created with Save
+// using the same encoding. The items which have expired in the meantime are skipped, the same as
+// the items whose key already exists in the cache. The items are loaded with their original expiration time.
+func (c *Cache[T, V]) Load(r io.Reader, enc Encoding) error {
+	var items []snapshotItem[T, V]
+
+	if c.closed.Load() {
+		return ErrorClosed
+	}
+
+	switch enc {
+	case Gob:
+		if err := gob.NewDecoder(r).Decode(&items); err != nil {
+			return err
+		}
+	case JSON:
+		if err := json.NewDecoder(r).Decode(&items); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported encoding: %v", enc)
+	}
+
+	var evicted []evictedItem[T, V]
+
+	now := time.Now().UnixNano()
+
+	c.mu.Lock()
+	for _, it := range items {
+		if it.Expiration > 0 && now > it.Expiration {
+			continue
+		}
+		if item, ok := c.items[it.Key]; ok {
+			if item.expiration <= 0 || now <= item.expiration {
+				continue
+			}
+		}
+		if c.opts.maxCost > 0 && it.Cost > c.opts.maxCost {
+			continue
+		}
+		evicted = append(evicted, c.insert(it.Key, &Item[V]{
+			object:     it.Object,
+			expiration: it.Expiration,
+			cost:       it.Cost,
+			ttl:        it.TTL,
+			deadline:   it.Deadline,
+			sliding:    it.Sliding,
+			tags:       it.Tags,
+		})...)
+	}
+	c.mu.Unlock()
+
+	return c.notify(evicted)
+}
+
+// SaveFile saves the cache items into the file defined by its path.
+// The file is created if it does not exist, otherwise it is truncated.
+func (c *Cache[T, V]) SaveFile(path string, enc Encoding) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Append(err, f.Close())
+	}()
+
+	return c.Save(f, enc)
+}
+
+// LoadFile restores the cache items from the file defined by its path.
+func (c *Cache[T, V]) LoadFile(path string, enc Encoding) (err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Append(err, f.Close())
+	}()
+
+	return c.Load(f, enc)
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_SaveLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, enc := range []Encoding{Gob, JSON} {
+		c1 := New[string, SampleStruct](NoExpiration, 0)
+		c1.Set("a", SampleStruct{Id: 1}, DefaultExpiration)
+		c1.Set("b", SampleStruct{Id: 2, Items: []*SampleStruct{{Id: 3}}}, time.Minute)
+		c1.Set("c", SampleStruct{Id: 4}, time.Millisecond)
+		<-time.After(5 * time.Millisecond)
+
+		var buf bytes.Buffer
+		err := c1.Save(&buf, enc)
+		assert.NoError(err)
+
+		c2 := New[string, SampleStruct](NoExpiration, 0)
+		c2.Set("a", SampleStruct{Id: 10}, DefaultExpiration)
+		err = c2.Load(&buf, enc)
+		assert.NoError(err)
+		assert.Equal(2, c2.Count())
+
+		// The existing items are not overwritten.
+		item, err := c2.Get("a")
+		assert.NoError(err)
+		assert.Equal(10, item.Val().Id)
+
+		// The expiration time is preserved.
+		item, err = c2.Get("b")
+		assert.NoError(err)
+		assert.Equal(2, item.Val().Id)
+		assert.Equal(3, item.Val().Items[0].Id)
+		orig, _ := c1.Get("b")
+		assert.Equal(orig.expiration, item.expiration)
+
+		_, err = c2.Get("c")
+		assert.Error(err)
+	}
+}
+
+func TestCache_LoadSkipExpired(t *testing.T) {
+	assert := assert.New(t)
+
+	c1 := New[string, int](NoExpiration, 0)
+	c1.Set("a", 1, 10*time.Millisecond)
+	c1.Set("b", 2, DefaultExpiration)
+
+	var buf bytes.Buffer
+	err := c1.Save(&buf, JSON)
+	assert.NoError(err)
+	<-time.After(20 * time.Millisecond)
+
+	c2 := New[string, int](NoExpiration, 0, WithCapacity(5))
+	err = c2.Load(&buf, JSON)
+	assert.NoError(err)
+	assert.Equal(1, c2.Count())
+	_, err = c2.Get("a")
+	assert.Error(err)
+
+	err = c2.Load(strings.NewReader("invalid"), JSON)
+	assert.Error(err)
+	err = c2.Load(strings.NewReader("[]"), Encoding(10))
+	assert.Error(err)
+	err = c2.Save(&buf, Encoding(10))
+	assert.Error(err)
+}
+
+func TestCache_SaveLoadFile(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "cache.gob")
+
+	c1 := New[string, string](NoExpiration, 0)
+	c1.Set("foo", "bar", DefaultExpiration)
+	err := c1.SaveFile(path, Gob)
+	assert.NoError(err)
+
+	c2 := New[string, string](NoExpiration, 0)
+	err = c2.LoadFile(path, Gob)
+	assert.NoError(err)
+	item, err := c2.Get("foo")
+	assert.NoError(err)
+	assert.Equal("bar", item.Val())
+
+	err = c2.LoadFile(filepath.Join(t.TempDir(), "missing.gob"), Gob)
+	assert.Error(err)
+	err = c2.SaveFile(t.TempDir(), Gob)
+	assert.Error(err)
+}
+
+func Example_saveLoad() {
+	c1 := New[string, string](NoExpiration, 0)
+	c1.Set("foo", "bar", DefaultExpiration)
+
+	var buf bytes.Buffer
+	c1.Save(&buf, JSON)
+
+	c2 := New[string, string](NoExpiration, 0)
+	c2.Load(&buf, JSON)
+	item, _ := c2.Get("foo")
+	fmt.Println(item.Val())
+
+	// Output:
+	// bar
+}
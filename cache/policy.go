@@ -0,0 +1,214 @@
+package cache
+
+import (
+	"container/list"
+)
+
+// EvictionPolicy defines the strategy used for selecting the item to be evicted
+// once the cache reaches its maximum capacity or its maximum cost.
+type EvictionPolicy int
+
+const (
+	// LRU evicts the least recently used item first.
+	LRU EvictionPolicy = iota
+	// LFU evicts the least frequently used item first. Items with the same
+	// access frequency are evicted in least recently used order.
+	LFU
+	// FIFO evicts the items in the order they were added into the cache.
+	FIFO
+)
+
+// String returns the name of the eviction policy.
+func (p EvictionPolicy) String() string {
+	switch p {
+	case LRU:
+		return "LRU"
+	case LFU:
+		return "LFU"
+	case FIFO:
+		return "FIFO"
+	}
+	return "unknown"
+}
+
+// evictor is the common interface implemented by the eviction policies.
+// The implementations are not thread safe, the caller is responsible for the synchronization.
+type evictor[T comparable] interface {
+	// add registers a new key.
+	add(key T)
+	// access is called each time an existing key is read.
+	access(key T)
+	// remove unregisters a key.
+	remove(key T)
+	// victim returns the next key to be evicted without unregistering it.
+	victim() (T, bool)
+	// reset unregisters all the keys.
+	reset()
+}
+
+// newEvictor returns the evictor implementation corresponding to the eviction policy.
+func newEvictor[T comparable](p EvictionPolicy) evictor[T] {
+	switch p {
+	case LFU:
+		return newLfuPolicy[T]()
+	case FIFO:
+		return newListPolicy[T](false)
+	default:
+		return newListPolicy[T](true)
+	}
+}
+
+// listPolicy keeps the keys in a doubly linked list, the most recent key being at the front.
+// If promote is true the accessed keys are moved in front of the list (LRU),
+// otherwise the list reflects the insertion order (FIFO).
+type listPolicy[T comparable] struct {
+	ll      *list.List
+	entries map[T]*list.Element
+	promote bool
+}
+
+func newListPolicy[T comparable](promote bool) *listPolicy[T] {
+	return &listPolicy[T]{
+		ll:      list.New(),
+		entries: make(map[T]*list.Element),
+		promote: promote,
+	}
+}
+
+func (p *listPolicy[T]) add(key T) {
+	if el, ok := p.entries[key]; ok {
+		p.ll.MoveToFront(el)
+		return
+	}
+	p.entries[key] = p.ll.PushFront(key)
+}
+
+func (p *listPolicy[T]) access(key T) {
+	if !p.promote {
+		return
+	}
+	if el, ok := p.entries[key]; ok {
+		p.ll.MoveToFront(el)
+	}
+}
+
+func (p *listPolicy[T]) remove(key T) {
+	if el, ok := p.entries[key]; ok {
+		p.ll.Remove(el)
+		delete(p.entries, key)
+	}
+}
+
+func (p *listPolicy[T]) victim() (T, bool) {
+	var key T
+	el := p.ll.Back()
+	if el == nil {
+		return key, false
+	}
+	return el.Value.(T), true
+}
+
+func (p *listPolicy[T]) reset() {
+	p.ll.Init()
+	p.entries = make(map[T]*list.Element)
+}
+
+// lfuEntry holds the key and its access frequency.
+type lfuEntry[T comparable] struct {
+	key  T
+	freq int
+}
+
+// lfuPolicy groups the keys into buckets by their access frequency.
+// Every bucket is a linked list ordered by recency, which makes all the operations O(1),
+// except the removal of the last key with the minimum frequency.
+type lfuPolicy[T comparable] struct {
+	entries map[T]*list.Element
+	buckets map[int]*list.List
+	minFreq int
+}
+
+func newLfuPolicy[T comparable]() *lfuPolicy[T] {
+	return &lfuPolicy[T]{
+		entries: make(map[T]*list.Element),
+		buckets: make(map[int]*list.List),
+	}
+}
+
+func (p *lfuPolicy[T]) add(key T) {
+	if _, ok := p.entries[key]; ok {
+		p.access(key)
+		return
+	}
+	p.entries[key] = p.bucket(1).PushFront(&lfuEntry[T]{key: key, freq: 1})
+	p.minFreq = 1
+}
+
+func (p *lfuPolicy[T]) access(key T) {
+	el, ok := p.entries[key]
+	if !ok {
+		return
+	}
+	entry := el.Value.(*lfuEntry[T])
+	p.unlink(el)
+	if _, ok := p.buckets[entry.freq]; !ok && p.minFreq == entry.freq {
+		p.minFreq++
+	}
+	entry.freq++
+	p.entries[key] = p.bucket(entry.freq).PushFront(entry)
+}
+
+func (p *lfuPolicy[T]) remove(key T) {
+	el, ok := p.entries[key]
+	if !ok {
+		return
+	}
+	entry := el.Value.(*lfuEntry[T])
+	p.unlink(el)
+	delete(p.entries, key)
+
+	if _, ok := p.buckets[entry.freq]; !ok && p.minFreq == entry.freq {
+		p.minFreq = 0
+		for freq := range p.buckets {
+			if p.minFreq == 0 || freq < p.minFreq {
+				p.minFreq = freq
+			}
+		}
+	}
+}
+
+func (p *lfuPolicy[T]) victim() (T, bool) {
+	var key T
+	l, ok := p.buckets[p.minFreq]
+	if !ok || l.Len() == 0 {
+		return key, false
+	}
+	return l.Back().Value.(*lfuEntry[T]).key, true
+}
+
+func (p *lfuPolicy[T]) reset() {
+	p.entries = make(map[T]*list.Element)
+	p.buckets = make(map[int]*list.List)
+	p.minFreq = 0
+}
+
+// bucket returns the list of keys having the requested frequency, creating it if it's missing.
+func (p *lfuPolicy[T]) bucket(freq int) *list.List {
+	l, ok := p.buckets[freq]
+	if !ok {
+		l = list.New()
+		p.buckets[freq] = l
+	}
+	return l
+}
+
+// unlink removes the list element from its frequency bucket and drops the bucket if it became empty.
+func (p *lfuPolicy[T]) unlink(el *list.Element) {
+	freq := el.Value.(*lfuEntry[T]).freq
+	if l, ok := p.buckets[freq]; ok {
+		l.Remove(el)
+		if l.Len() == 0 {
+			delete(p.buckets, freq)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_EvictionLRU(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New[string, int](NoExpiration, 0, WithCapacity(3))
+	c.Set("a", 1, DefaultExpiration)
+	c.Set("b", 2, DefaultExpiration)
+	c.Set("c", 3, DefaultExpiration)
+	assert.Equal(3, c.Count())
+
+	// Promote "a", so "b" becomes the least recently used item.
+	_, err := c.Get("a")
+	assert.NoError(err)
+
+	err = c.Set("d", 4, DefaultExpiration)
+	assert.NoError(err)
+	assert.Equal(3, c.Count())
+	_, err = c.Get("b")
+	assert.Error(err)
+
+	for _, k := range []string{"a", "c", "d"} {
+		_, err = c.Get(k)
+		assert.NoError(err)
+	}
+
+	// Updating an existing item should not evict anything.
+	err = c.Update("c", 30, DefaultExpiration)
+	assert.NoError(err)
+	assert.Equal(3, c.Count())
+
+	c.Set("e", 5, DefaultExpiration)
+	_, err = c.Get("a")
+	assert.Error(err)
+
+	c.Delete("c")
+	assert.Equal(2, c.Count())
+	c.Set("f", 6, DefaultExpiration)
+	assert.Equal(3, c.Count())
+
+	c.Flush()
+	assert.Equal(0, c.Count())
+	c.Set("g", 7, DefaultExpiration)
+	c.Set("h", 8, DefaultExpiration)
+	c.Set("i", 9, DefaultExpiration)
+	c.Set("j", 10, DefaultExpiration)
+	_, err = c.Get("g")
+	assert.Error(err)
+	assert.Equal(3, c.Count())
+}
+
+func TestCache_EvictionFailedSet(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New[string, int](NoExpiration, 0, WithCapacity(2))
+	c.Set("a", 1, DefaultExpiration)
+	c.Set("b", 2, DefaultExpiration)
+
+	// A failed Set is not an access, so "a" remains the least recently used item.
+	assert.Error(c.Set("a", 1, DefaultExpiration))
+	assert.NoError(c.Set("c", 3, DefaultExpiration))
+	_, err := c.Get("a")
+	assert.Error(err)
+	_, err = c.Get("b")
+	assert.NoError(err)
+
+	// Neither it extends the sliding expiration.
+	c = New[string, int](NoExpiration, 0)
+	c.Set("a", 1, time.Minute, WithSliding(0))
+	exp := c.items["a"].expiration
+	<-time.After(time.Millisecond)
+	assert.Error(c.Set("a", 1, DefaultExpiration))
+	assert.Equal(exp, c.items["a"].expiration)
+}
+
+func TestCache_EvictionLFU(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New[string, int](NoExpiration, 0, WithCapacity(3), WithPolicy(LFU))
+	c.Set("a", 1, DefaultExpiration)
+	c.Set("b", 2, DefaultExpiration)
+	c.Set("c", 3, DefaultExpiration)
+
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	c.Get("c")
+
+	// "b" and "c" have the same frequency, but "b" has been accessed first.
+	c.Set("d", 4, DefaultExpiration)
+	_, err := c.Get("b")
+	assert.Error(err)
+
+	// The newly added item has the lowest frequency.
+	c.Set("e", 5, DefaultExpiration)
+	_, err = c.Get("d")
+	assert.Error(err)
+
+	for _, k := range []string{"a", "c", "e"} {
+		_, err = c.Get(k)
+		assert.NoError(err)
+	}
+
+	c.Delete("e")
+	c.Set("f", 6, DefaultExpiration)
+	c.Set("g", 7, DefaultExpiration)
+	_, err = c.Get("f")
+	assert.Error(err)
+	assert.Equal(3, c.Count())
+}
+
+func TestCache_EvictionFIFO(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New[string, int](NoExpiration, 0, WithCapacity(2), WithPolicy(FIFO))
+	c.Set("a", 1, DefaultExpiration)
+	c.Set("b", 2, DefaultExpiration)
+
+	// Accessing an item does not change the eviction order.
+	c.Get("a")
+	c.Set("c", 3, DefaultExpiration)
+	_, err := c.Get("a")
+	assert.Error(err)
+
+	c.Set("d", 4, DefaultExpiration)
+	_, err = c.Get("b")
+	assert.Error(err)
+	assert.Equal(2, c.Count())
+}
+
+func TestCache_EvictionMaxCost(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New[string, string](NoExpiration, 0, WithMaxCost(10))
+	err := c.Set("a", "foo", DefaultExpiration, WithCost(4))
+	assert.NoError(err)
+	err = c.Set("b", "bar", DefaultExpiration, WithCost(4))
+	assert.NoError(err)
+	err = c.Set("c", "baz", DefaultExpiration)
+	assert.NoError(err)
+	assert.Equal(3, c.Count())
+
+	// Only the least recently used item has to be evicted to make room for the new item.
+	err = c.Set("d", "qux", DefaultExpiration, WithCost(5))
+	assert.NoError(err)
+	assert.Equal(3, c.Count())
+	_, err = c.Get("a")
+	assert.Error(err)
+
+	err = c.Set("e", "quux", DefaultExpiration, WithCost(8))
+	assert.NoError(err)
+	assert.Equal(1, c.Count())
+
+	err = c.Set("f", "quuz", DefaultExpiration, WithCost(11))
+	assert.Error(err)
+	assert.Equal(1, c.Count())
+}
+
+func TestEvictionPolicy_String(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("LRU", LRU.String())
+	assert.Equal("LFU", LFU.String())
+	assert.Equal("FIFO", FIFO.String())
+	assert.Equal("unknown", EvictionPolicy(10).String())
+}
+
+func Example_eviction() {
+	c := New[string, int](NoExpiration, 0, WithCapacity(2), WithPolicy(LRU))
+	c.Set("a", 1, DefaultExpiration)
+	c.Set("b", 2, DefaultExpiration)
+	c.Get("a")
+	c.Set("c", 3, DefaultExpiration)
+
+	_, err := c.Get("b")
+	fmt.Println(err)
+	fmt.Println(c.Count())
+
+	// Output:
+	// item with key 'b' not found
+	// 2
+}
@@ -0,0 +1,281 @@
+package cache
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+type sharded[T comparable, V any] struct {
+	shards     []*Cache[T, V]
+	seed       maphash.Seed
+	hasher     func(T) uint64
+	done       chan struct{}
+	stop       sync.Once
+	closed     atomic.Bool
+	ctx        context.Context
+	cleanupInt time.Duration
+}
+
+// Sharded is a cache variant which splits the items into multiple shards, each shard being
+// guarded by its own lock. The shard of an item is selected by hashing its key.
+// This reduces the lock contention under highly concurrent read and write operations.
+type Sharded[T comparable, V any] struct {
+	*sharded[T, V]
+}
+
+// NewSharded instantiates a sharded cache with n shards. The expiration time and the cleanup interval
+// have the same meaning as in case of New, but the expired items of all the shards are removed
+// by a single cleanup goroutine. The options are applied to each shard individually, which means
+// that WithCapacity and WithMaxCost are limiting the size of a single shard.
+// If n is less than or equal to zero, the number of shards equals the number of CPUs.
+//
+// The keys are hashed by their value, the structs and the arrays field by field. The pointers and channels
+// are hashed by their address. A custom hash function, e.g. a faster one for struct keys, can be provided with WithHasher.
+// The cleanup goroutine should be stopped by calling Close once the cache is not needed anymore.
+func NewSharded[T comparable, V any](n int, expTime, cleanupTime time.Duration, opts ...Option) *Sharded[T, V] {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var hasher func(T) uint64
+	if o.hasher != nil {
+		fn, ok := o.hasher.(func(T) uint64)
+		if !ok {
+			panic(fmt.Sprintf("cache: the hash function should be of type func(%s) uint64", reflect.TypeOf((*T)(nil)).Elem()))
+		}
+		hasher = fn
+	}
+
+	s := &sharded[T, V]{
+		shards:     make([]*Cache[T, V], n),
+		seed:       maphash.MakeSeed(),
+		hasher:     hasher,
+		done:       make(chan struct{}),
+		ctx:        o.ctx,
+		cleanupInt: cleanupTime,
+	}
+	for i := range s.shards {
+		s.shards[i] = &Cache[T, V]{newCache(expTime, cleanupTime, make(map[T]*Item[V]), o)}
+	}
+
+	sc := &Sharded[T, V]{s}
+	if cleanupTime > 0 {
+		go s.cleanup()
+		runtime.SetFinalizer(sc, stopShardedCleanup[T, V])
+	}
+
+	return sc
+}
+
+// shard returns the shard responsible for the provided key.
+func (s *sharded[T, V]) shard(key T) *Cache[T, V] {
+	return s.shards[s.hash(key)%uint64(len(s.shards))]
+}
+
+// hash computes the hash of the key. The hash function provided with WithHasher takes precedence,
+// otherwise the key is hashed by its kind, so that equal keys always end up in the same shard.
+func (s *sharded[T, V]) hash(key T) uint64 {
+	if s.hasher != nil {
+		return s.hasher(key)
+	}
+
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(s.seed, k)
+	case int:
+		return s.hashUint64(uint64(k))
+	case int64:
+		return s.hashUint64(uint64(k))
+	case uint64:
+		return s.hashUint64(k)
+	}
+
+	return s.hashValue(reflect.ValueOf(key))
+}
+
+// hashValue hashes the key by its kind. The pointers and channels are hashed by their address
+// and the negative zero floats are hashed as positive zero, since they are equal as map keys.
+// The structs, the arrays and the interfaces are hashed recursively.
+func (s *sharded[T, V]) hashValue(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.String:
+		return maphash.String(s.seed, v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return s.hashUint64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return s.hashUint64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return s.hashUint64(math.Float64bits(normZero(v.Float())))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		re, im := math.Float64bits(normZero(real(c))), math.Float64bits(normZero(imag(c)))
+		return s.hashUint64(re) + 31*s.hashUint64(im)
+	case reflect.Bool:
+		if v.Bool() {
+			return s.hashUint64(1)
+		}
+		return s.hashUint64(0)
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return s.hashUint64(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return s.hashUint64(0)
+		}
+		return s.hashValue(v.Elem())
+	case reflect.Struct:
+		var h uint64
+		for i := 0; i < v.NumField(); i++ {
+			h = 31*h + s.hashValue(v.Field(i))
+		}
+		return h
+	case reflect.Array:
+		var h uint64
+		for i := 0; i < v.Len(); i++ {
+			h = 31*h + s.hashValue(v.Index(i))
+		}
+		return h
+	}
+
+	// The other kinds (slices, maps and functions) are not comparable, so they can't be used as map keys.
+	panic(fmt.Sprintf("cache: key type %s is not comparable", v.Type()))
+}
+
+// hashUint64 hashes the binary representation of n.
+func (s *sharded[T, V]) hashUint64(n uint64) uint64 {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], n)
+
+	return maphash.Bytes(s.seed, buf[:])
+}
+
+// normZero converts the negative zero to positive zero.
+func normZero(f float64) float64 {
+	if f == 0 {
+		return 0
+	}
+	return f
+}
+
+// Set inserts a new item into the shard selected by the key.
+// In case an item with the specified key already exists it will return an error.
+func (s *Sharded[T, V]) Set(key T, val V, d time.Duration, opts ...ItemOption) error {
+	return s.shard(key).Set(key, val, d, opts...)
+}
+
+// SetDefault adds a new item into the cache with the default expiration time.
+func (s *Sharded[T, V]) SetDefault(key T, val V, opts ...ItemOption) error {
+	return s.shard(key).SetDefault(key, val, opts...)
+}
+
+// Get returns a cache item defined by its key. If the item is expired an error is returned.
+func (s *Sharded[T, V]) Get(key T) (*Item[V], error) {
+	return s.shard(key).Get(key)
+}
+
+// Update replaces a cache item with the new value.
+func (s *Sharded[T, V]) Update(key T, val V, d time.Duration, opts ...ItemOption) error {
+	return s.shard(key).Update(key, val, d, opts...)
+}
+
+// Delete removes a cache item.
+func (s *Sharded[T, V]) Delete(key T) error {
+	return s.shard(key).Delete(key)
+}
+
+// DeleteExpired removes all the expired items from all the shards.
+func (s *sharded[T, V]) DeleteExpired() error {
+	var err error
+
+	for _, c := range s.shards {
+		err = multierr.Append(err, c.DeleteExpired())
+	}
+
+	return err
+}
+
+// Flush removes all the existing items from all the shards.
+func (s *Sharded[T, V]) Flush() {
+	for _, c := range s.shards {
+		c.Flush()
+	}
+}
+
+// Count returns the number of existing items in the cache.
+func (s *Sharded[T, V]) Count() int {
+	var n int
+
+	for _, c := range s.shards {
+		n += c.Count()
+	}
+
+	return n
+}
+
+// OnEvicted sets the eviction callback on all the shards. See Cache.OnEvicted for the details.
+func (s *Sharded[T, V]) OnEvicted(fn func(key T, val V, reason EvictionReason) error) {
+	for _, c := range s.shards {
+		c.OnEvicted(fn)
+	}
+}
+
+// Close stops the cleanup goroutine and closes all the shards.
+// After the cache has been closed, the operations which are returning an error will fail with ErrorClosed.
+// Closing an already closed cache returns ErrorClosed.
+func (s *Sharded[T, V]) Close() error {
+	if !s.closed.CompareAndSwap(false, true) {
+		return ErrorClosed
+	}
+	s.stop.Do(func() {
+		close(s.done)
+	})
+	for _, c := range s.shards {
+		c.Close()
+	}
+
+	return nil
+}
+
+// cleanup removes the expired items of all the shards at the specified time interval.
+// It stops when the cache is closed or the context provided on cache initialization is canceled.
+func (s *sharded[T, V]) cleanup() {
+	var ctxDone <-chan struct{}
+	if s.ctx != nil {
+		ctxDone = s.ctx.Done()
+	}
+
+	tick := time.NewTicker(s.cleanupInt)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.C:
+			s.DeleteExpired()
+		case <-s.done:
+			return
+		case <-ctxDone:
+			return
+		}
+	}
+}
+
+// stopShardedCleanup stops the cleanup process once the sharded cache became unreachable.
+func stopShardedCleanup[T comparable, V any](s *Sharded[T, V]) {
+	s.stop.Do(func() {
+		close(s.done)
+	})
+}
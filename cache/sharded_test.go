@@ -0,0 +1,273 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharded_Basic(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewSharded[string, int](4, DefaultExpiration, time.Minute)
+	assert.Len(c.shards, 4)
+
+	for i := 0; i < 100; i++ {
+		err := c.Set("item"+strconv.Itoa(i), i, DefaultExpiration)
+		assert.NoError(err)
+	}
+	assert.Equal(100, c.Count())
+
+	err := c.Set("item1", 1, DefaultExpiration)
+	assert.Error(err)
+
+	item, err := c.Get("item10")
+	assert.NoError(err)
+	assert.Equal(10, item.Val())
+
+	err = c.Update("item10", 20, DefaultExpiration)
+	assert.NoError(err)
+	item, _ = c.Get("item10")
+	assert.Equal(20, item.Val())
+
+	err = c.Delete("item10")
+	assert.NoError(err)
+	_, err = c.Get("item10")
+	assert.Error(err)
+	assert.Equal(99, c.Count())
+
+	err = c.SetDefault("item10", 10)
+	assert.NoError(err)
+
+	c.Flush()
+	assert.Equal(0, c.Count())
+
+	c = NewSharded[string, int](0, NoExpiration, 0)
+	assert.NotEmpty(c.shards)
+}
+
+func TestSharded_Expiration(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu      sync.Mutex
+		evicted []string
+	)
+
+	c := NewSharded[string, int](8, 5*time.Millisecond, 20*time.Millisecond)
+	c.OnEvicted(func(key string, val int, reason EvictionReason) error {
+		mu.Lock()
+		evicted = append(evicted, key)
+		mu.Unlock()
+		return nil
+	})
+	c.Set("a", 1, DefaultExpiration)
+	c.Set("b", 2, NoExpiration)
+	c.Set("c", 3, DefaultExpiration)
+	<-time.After(100 * time.Millisecond)
+	assert.Equal(1, c.Count())
+
+	mu.Lock()
+	assert.ElementsMatch([]string{"a", "c"}, evicted)
+	mu.Unlock()
+
+	c.Set("d", 4, time.Millisecond)
+	<-time.After(5 * time.Millisecond)
+	err := c.DeleteExpired()
+	assert.NoError(err)
+	assert.Equal(1, c.Count())
+}
+
+func TestSharded_Concurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewSharded[string, int](16, NoExpiration, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("%d-%d", n, j)
+				c.Set(key, j, DefaultExpiration)
+				c.Get(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(800, c.Count())
+}
+
+func Example_sharded() {
+	c := NewSharded[string, string](4, DefaultExpiration, time.Minute)
+	c.Set("foo", "bar", DefaultExpiration)
+	item, _ := c.Get("foo")
+	fmt.Println(item.Val())
+	fmt.Println(c.Count())
+
+	// Output:
+	// bar
+	// 1
+}
+
+func benchmarkParallel(b *testing.B, set func(string, int) error, get func(string) (*Item[int], error)) {
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = "item" + strconv.Itoa(i)
+		set(keys[i], i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := keys[i%len(keys)]
+			if i%4 == 0 {
+				set(key, i)
+			} else {
+				get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkCache_Parallel(b *testing.B) {
+	c := New[string, int](NoExpiration, 0)
+	benchmarkParallel(b, func(key string, val int) error {
+		return c.Update(key, val, DefaultExpiration)
+	}, c.Get)
+}
+
+func BenchmarkSharded_Parallel(b *testing.B) {
+	c := NewSharded[string, int](32, NoExpiration, 0)
+	benchmarkParallel(b, func(key string, val int) error {
+		return c.Update(key, val, DefaultExpiration)
+	}, c.Get)
+}
+
+func TestSharded_ComparableKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	type key struct {
+		tenant string
+		id     int
+	}
+	type name string
+
+	c1 := NewSharded[int, int](8, NoExpiration, 0)
+	c2 := NewSharded[uint16, int](8, NoExpiration, 0)
+	c3 := NewSharded[key, int](8, NoExpiration, 0, WithHasher(func(k key) uint64 {
+		return uint64(k.id)
+	}))
+	c4 := NewSharded[name, int](8, NoExpiration, 0)
+	for i := 0; i < 100; i++ {
+		c1.Set(i, i, DefaultExpiration)
+		c2.Set(uint16(i), i, DefaultExpiration)
+		c3.Set(key{"foo", i}, i, DefaultExpiration)
+		c4.Set(name(strconv.Itoa(i)), i, DefaultExpiration)
+	}
+	for i := 0; i < 100; i++ {
+		item, err := c1.Get(i)
+		assert.NoError(err)
+		assert.Equal(i, item.Val())
+		item, err = c2.Get(uint16(i))
+		assert.NoError(err)
+		assert.Equal(i, item.Val())
+		item, err = c3.Get(key{"foo", i})
+		assert.NoError(err)
+		assert.Equal(i, item.Val())
+		item, err = c4.Get(name(strconv.Itoa(i)))
+		assert.NoError(err)
+		assert.Equal(i, item.Val())
+	}
+
+	// The keys should be distributed between multiple shards.
+	for _, c := range []interface{ Count() int }{c1.shards[0], c3.shards[0], c4.shards[0]} {
+		assert.Less(c.Count(), 100)
+	}
+}
+
+func TestSharded_KeyEquality(t *testing.T) {
+	assert := assert.New(t)
+
+	type node struct{ val int }
+
+	c1 := NewSharded[*node, int](8, NoExpiration, 0)
+	nodes := make([]*node, 100)
+	for i := range nodes {
+		nodes[i] = &node{i}
+		c1.Set(nodes[i], i, DefaultExpiration)
+	}
+	for i, n := range nodes {
+		n.val = -i - 1
+		item, err := c1.Get(n)
+		assert.NoError(err)
+		assert.Equal(i, item.Val())
+	}
+
+	negZero := math.Copysign(0, -1)
+	c2 := NewSharded[float64, int](8, NoExpiration, 0)
+	assert.NoError(c2.Set(0.0, 1, DefaultExpiration))
+	item, err := c2.Get(negZero)
+	assert.NoError(err)
+	assert.Equal(1, item.Val())
+
+	type key struct {
+		id   int
+		name string
+		tags [2]float64
+		ptr  *node
+	}
+	c3 := NewSharded[key, int](8, NoExpiration, 0)
+	for i := 0; i < 100; i++ {
+		c3.Set(key{i, "foo", [2]float64{0, float64(i)}, nodes[i]}, i, DefaultExpiration)
+	}
+	for i := 0; i < 100; i++ {
+		item, err := c3.Get(key{i, "foo", [2]float64{negZero, float64(i)}, nodes[i]})
+		assert.NoError(err)
+		assert.Equal(i, item.Val())
+	}
+	assert.Less(c3.shards[0].Count(), 100)
+
+	// The interface keys are hashed by their dynamic value.
+	var k1, k2 any = struct{ A int }{1}, [2]string{"a", "b"}
+	assert.NotPanics(func() {
+		assert.Equal(c3.hashValue(reflect.ValueOf(struct{ A int }{1})), c3.hashValue(reflect.ValueOf(&k1).Elem()))
+		assert.Equal(c3.hashValue(reflect.ValueOf([2]string{"a", "b"})), c3.hashValue(reflect.ValueOf(&k2).Elem()))
+	})
+
+	assert.Panics(func() { NewSharded[key, int](8, NoExpiration, 0, WithHasher(func(k int) uint64 { return 0 })) })
+}
+
+func TestSharded_Close(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewSharded[string, int](4, DefaultExpiration, 5*time.Millisecond)
+	c.Set("a", 1, 10*time.Millisecond)
+	assert.NoError(c.Close())
+	assert.ErrorIs(c.Close(), ErrorClosed)
+
+	<-time.After(30 * time.Millisecond)
+	assert.Equal(1, c.Count())
+	_, err := c.Get("a")
+	assert.ErrorIs(err, ErrorClosed)
+	assert.ErrorIs(c.Set("b", 2, DefaultExpiration), ErrorClosed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c = NewSharded[string, int](4, DefaultExpiration, 5*time.Millisecond, WithContext(ctx))
+	cancel()
+	<-time.After(10 * time.Millisecond)
+	c.Set("a", 1, time.Millisecond)
+	<-time.After(30 * time.Millisecond)
+	assert.Equal(1, c.Count())
+	assert.NoError(c.Close())
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"sync/atomic"
+)
+
+// Stats is a snapshot of the cache statistics.
+type Stats struct {
+	// Hits is the number of lookups which found a valid item.
+	Hits uint64
+	// Misses is the number of lookups for nonexistent or expired items.
+	Misses uint64
+	// Evictions is the number of items evicted to make room for new items.
+	Evictions uint64
+	// Expirations is the number of expired items removed from the cache.
+	Expirations uint64
+	// Size is the number of items stored in the cache, including the expired items not yet removed.
+	Size int
+	// Cost is the total cost of the items stored in the cache.
+	Cost int64
+}
+
+// HitRatio returns the ratio of the hits from the total number of lookups.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// MetricsHook is an optional interface which can be used for exporting the cache statistics
+// into an external metrics system. The methods are invoked outside of the cache lock.
+type MetricsHook interface {
+	// OnHit is invoked on each lookup which found a valid item.
+	OnHit()
+	// OnMiss is invoked on each lookup for a nonexistent or expired item.
+	OnMiss()
+	// OnEviction is invoked each time an item is removed from the cache.
+	OnEviction(reason EvictionReason)
+}
+
+// WithMetrics registers a metrics hook which is notified about the cache lookups and evictions.
+func WithMetrics(h MetricsHook) Option {
+	return func(o *options) {
+		o.metrics = h
+	}
+}
+
+// counters holds the cache statistics. The counters are updated atomically,
+// so they can be modified while holding only the read lock.
+type counters struct {
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	evictions   atomic.Uint64
+	expirations atomic.Uint64
+}
+
+// record updates the hit or miss counter depending on the lookup result.
+func (c *cache[T, V]) record(hit bool) {
+	if hit {
+		c.stats.hits.Add(1)
+		if c.opts.metrics != nil {
+			c.opts.metrics.OnHit()
+		}
+		return
+	}
+	c.stats.misses.Add(1)
+	if c.opts.metrics != nil {
+		c.opts.metrics.OnMiss()
+	}
+}
+
+// removed updates the counters corresponding to the eviction reason.
+func (c *cache[T, V]) removed(reason EvictionReason) {
+	switch reason {
+	case Capacity:
+		c.stats.evictions.Add(1)
+	case Expired:
+		c.stats.expirations.Add(1)
+	}
+}
+
+// Stats returns a snapshot of the cache statistics.
+func (c *Cache[T, V]) Stats() Stats {
+	c.mu.RLock()
+	size, cost := len(c.items), c.cost
+	c.mu.RUnlock()
+
+	return Stats{
+		Hits:        c.stats.hits.Load(),
+		Misses:      c.stats.misses.Load(),
+		Evictions:   c.stats.evictions.Load(),
+		Expirations: c.stats.expirations.Load(),
+		Size:        size,
+		Cost:        cost,
+	}
+}
+
+// Stats returns the cache statistics aggregated from all the shards.
+func (s *Sharded[T, V]) Stats() Stats {
+	var st Stats
+
+	for _, c := range s.shards {
+		cs := c.Stats()
+		st.Hits += cs.Hits
+		st.Misses += cs.Misses
+		st.Evictions += cs.Evictions
+		st.Expirations += cs.Expirations
+		st.Size += cs.Size
+		st.Cost += cs.Cost
+	}
+
+	return st
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type metricsHook struct {
+	mu        sync.Mutex
+	hits      int
+	misses    int
+	evictions map[EvictionReason]int
+}
+
+func (m *metricsHook) OnHit() {
+	m.mu.Lock()
+	m.hits++
+	m.mu.Unlock()
+}
+
+func (m *metricsHook) OnMiss() {
+	m.mu.Lock()
+	m.misses++
+	m.mu.Unlock()
+}
+
+func (m *metricsHook) OnEviction(reason EvictionReason) {
+	m.mu.Lock()
+	m.evictions[reason]++
+	m.mu.Unlock()
+}
+
+func TestCache_Stats(t *testing.T) {
+	assert := assert.New(t)
+
+	hook := &metricsHook{evictions: make(map[EvictionReason]int)}
+	c := New[string, int](NoExpiration, 0, WithCapacity(2), WithMetrics(hook))
+	assert.Equal(Stats{}, c.Stats())
+	assert.Equal(0.0, c.Stats().HitRatio())
+
+	// The internal lookups executed by Set and Update are not recorded.
+	c.Set("a", 1, DefaultExpiration)
+	c.Set("b", 2, DefaultExpiration)
+	c.Update("b", 3, DefaultExpiration)
+	assert.Equal(uint64(0), c.Stats().Hits+c.Stats().Misses)
+
+	c.Get("a")
+	c.Get("b")
+	c.Get("c")
+	c.Set("c", 3, time.Millisecond)
+	c.GetOrSet("c", 4, DefaultExpiration)
+	c.GetOrLoad("d", func() (int, error) {
+		return 4, nil
+	})
+	<-time.After(5 * time.Millisecond)
+	c.DeleteExpired()
+	c.Set("e", 5, DefaultExpiration)
+	c.Delete("e")
+
+	st := c.Stats()
+	assert.Equal(uint64(3), st.Hits)
+	assert.Equal(uint64(2), st.Misses)
+	assert.Equal(uint64(2), st.Evictions)
+	assert.Equal(uint64(1), st.Expirations)
+	assert.Equal(1, st.Size)
+	assert.Equal(int64(1), st.Cost)
+	assert.Equal(0.6, st.HitRatio())
+
+	assert.Equal(3, hook.hits)
+	assert.Equal(2, hook.misses)
+	assert.Equal(2, hook.evictions[Capacity])
+	assert.Equal(1, hook.evictions[Deleted])
+
+	c.Set("f", 6, time.Millisecond)
+	<-time.After(5 * time.Millisecond)
+	c.DeleteExpired()
+	assert.Equal(uint64(2), c.Stats().Expirations)
+	assert.Equal(2, hook.evictions[Expired])
+
+	c.Flush()
+	assert.Equal(1, hook.evictions[Flushed])
+	assert.Equal(0, c.Stats().Size)
+}
+
+func TestSharded_Stats(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewSharded[string, int](4, NoExpiration, 0)
+	for i := 0; i < 10; i++ {
+		c.Set(fmt.Sprintf("item%d", i), i, DefaultExpiration)
+		c.Get(fmt.Sprintf("item%d", i))
+		c.Get(fmt.Sprintf("missing%d", i))
+	}
+
+	st := c.Stats()
+	assert.Equal(uint64(10), st.Hits)
+	assert.Equal(uint64(10), st.Misses)
+	assert.Equal(10, st.Size)
+	assert.Equal(int64(10), st.Cost)
+}
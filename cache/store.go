@@ -0,0 +1,232 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// Store is the interface implemented by the slower backing stores (like a file based key-value store
+// or a database table) placed behind the cache. The cache loads the missing items from the store
+// and propagates the writes and the manual deletions into the store. The items evicted from the cache
+// because of expiration or capacity limits are not deleted from the store.
+type Store[T comparable, V any] interface {
+	// Load returns the value stored under the key. It should return an error if the key does not exist.
+	Load(key T) (V, error)
+	// Save stores the value under the key.
+	Save(key T, val V) error
+	// Delete removes the value stored under the key.
+	Delete(key T) error
+}
+
+// storeOp is a pending write-behind operation.
+type storeOp[V any] struct {
+	val V
+	del bool
+}
+
+// WithWriteBehind makes the cache propagate the writes into the backing store asynchronously.
+// The writes are collected and flushed in batches by a background goroutine at the specified interval,
+// or earlier, once the number of pending writes reaches the batch size. Multiple writes of the same key
+// are merged, only the latest one being propagated. The failed writes are retried on the next flush.
+// Without this option the writes are propagated synchronously (write-through).
+func WithWriteBehind(interval time.Duration, batchSize int) Option {
+	return func(o *options) {
+		o.writeBehind = true
+		o.flushInterval = interval
+		o.batchSize = batchSize
+	}
+}
+
+// NewWithStore instantiates a cache placed in front of a backing store. Apart from the store,
+// the arguments have the same meaning as in case of New. On cache misses Get loads the item from the store
+// (read-through) and stores it in the cache with the default expiration time. The inserted, updated and
+// manually deleted items are propagated into the store either synchronously (write-through)
+// or asynchronously (write-behind), if the WithWriteBehind option is provided.
+// In write-behind mode Close should be called to flush the pending writes.
+func NewWithStore[T comparable, V any](store Store[T, V], expTime, cleanupTime time.Duration, opts ...Option) *Cache[T, V] {
+	return build(store, expTime, cleanupTime, opts...)
+}
+
+// readThrough loads the item from the backing store and stores it into the cache.
+// Concurrent loads of the same key are deduplicated.
+func (c *cache[T, V]) readThrough(key T) (*Item[V], error) {
+	return c.group.Do(key, func() (*Item[V], error) {
+		// The key is locked, so that a concurrent update can't be overwritten with the old store content.
+		unlock := c.lockKey(key)
+		if item, err := c.get(key); err == nil {
+			unlock()
+			return item, nil
+		}
+
+		// The pending and the in-flight write-behind operations are more recent than the store content.
+		c.wmu.Lock()
+		op, pending := c.pending[key]
+		if !pending {
+			op, pending = c.inflight[key]
+		}
+		c.wmu.Unlock()
+
+		var (
+			val V
+			err error
+		)
+		switch {
+		case pending && op.del:
+			unlock()
+			return nil, fmt.Errorf("item with key '%v' not found", key)
+		case pending:
+			val = op.val
+		default:
+			if val, err = c.store.Load(key); err != nil {
+				unlock()
+				return nil, err
+			}
+		}
+
+		c.mu.Lock()
+		item, err := c.newItem(key, val, DefaultExpiration)
+		if err != nil {
+			c.mu.Unlock()
+			unlock()
+			return nil, err
+		}
+		evicted := c.insert(key, item)
+		c.mu.Unlock()
+		unlock()
+
+		return item, c.notify(evicted)
+	})
+}
+
+// lockKey serializes the cache and the store changes of the same key, so that the store ends up
+// with the same value as the cache. It returns the function releasing the lock.
+// Without a backing store the key is not locked.
+func (c *cache[T, V]) lockKey(key T) func() {
+	if c.store == nil {
+		return func() {}
+	}
+	c.keys.lock(key)
+	return func() { c.keys.unlock(key) }
+}
+
+// write propagates the value into the backing store.
+func (c *cache[T, V]) write(key T, val V) error {
+	if c.store == nil {
+		return nil
+	}
+	if c.opts.writeBehind {
+		c.enqueue(key, storeOp[V]{val: val})
+		return nil
+	}
+	return c.store.Save(key, val)
+}
+
+// erase propagates the deletion into the backing store.
+func (c *cache[T, V]) erase(key T) error {
+	if c.store == nil {
+		return nil
+	}
+	if c.opts.writeBehind {
+		c.enqueue(key, storeOp[V]{del: true})
+		return nil
+	}
+	return c.store.Delete(key)
+}
+
+// enqueue registers a write-behind operation, overwriting the pending operation of the same key.
+func (c *cache[T, V]) enqueue(key T, op storeOp[V]) {
+	c.wmu.Lock()
+	c.pending[key] = op
+	n := len(c.pending)
+	c.wmu.Unlock()
+
+	if c.opts.batchSize > 0 && n >= c.opts.batchSize {
+		select {
+		case c.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Sync flushes the pending write-behind operations into the backing store.
+// It returns the combined errors of the failed operations, which are retried on the next flush.
+func (c *Cache[T, V]) Sync() error {
+	if c.store == nil || !c.opts.writeBehind {
+		return nil
+	}
+	return c.flush()
+}
+
+// flush writes the pending operations into the backing store. The flushes are serialized,
+// so the operations of the same key are propagated in the order they have been registered.
+func (c *cache[T, V]) flush() error {
+	var err error
+
+	c.fmu.Lock()
+	defer c.fmu.Unlock()
+
+	// The batch remains visible to the read-through loads until it's written into the store.
+	c.wmu.Lock()
+	batch := c.pending
+	c.pending = make(map[T]storeOp[V])
+	c.inflight = batch
+	c.wmu.Unlock()
+
+	defer func() {
+		c.wmu.Lock()
+		c.inflight = nil
+		c.wmu.Unlock()
+	}()
+
+	for key, op := range batch {
+		var e error
+		if op.del {
+			e = c.store.Delete(key)
+		} else {
+			e = c.store.Save(key, op.val)
+		}
+		if e == nil {
+			continue
+		}
+		err = multierr.Append(err, e)
+
+		// Retry the failed operation on the next flush, unless it has been overwritten meanwhile.
+		c.wmu.Lock()
+		if _, ok := c.pending[key]; !ok {
+			c.pending[key] = op
+		}
+		c.wmu.Unlock()
+	}
+
+	return err
+}
+
+// writer flushes the pending write-behind operations at the specified interval,
+// or when the number of the pending operations reaches the batch size.
+func (c *cache[T, V]) writer() {
+	interval := c.opts.flushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.C:
+			c.flush()
+		case <-c.flushCh:
+			c.flush()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// isMiss checks if the error returned by the cache lookup is caused by a missing or an expired item.
+func isMiss(err error) bool {
+	return err != nil && !errors.Is(err, ErrorClosed)
+}
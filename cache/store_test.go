@@ -0,0 +1,360 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mapStore struct {
+	mu    sync.Mutex
+	data  map[string]int
+	loads int32
+	fail  bool
+	// blockOn names the operation ("load", "save" or "delete") whose next call
+	// signals on started once it has accessed the data, then waits for release.
+	blockOn string
+	started chan struct{}
+	release chan struct{}
+}
+
+func newMapStore() *mapStore {
+	return &mapStore{data: make(map[string]int)}
+}
+
+func (s *mapStore) Load(key string) (int, error) {
+	atomic.AddInt32(&s.loads, 1)
+	defer s.pause("load")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v, ok := s.data[key]; ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("key '%v' not found in store", key)
+}
+
+func (s *mapStore) Save(key string, val int) error {
+	defer s.pause("save")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fail {
+		return fmt.Errorf("store unavailable")
+	}
+	s.data[key] = val
+	return nil
+}
+
+func (s *mapStore) Delete(key string) error {
+	defer s.pause("delete")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fail {
+		return fmt.Errorf("store unavailable")
+	}
+	delete(s.data, key)
+	return nil
+}
+
+// block makes the next call of the operation wait until unblock is called.
+func (s *mapStore) block(op string) {
+	s.mu.Lock()
+	s.blockOn = op
+	s.started = make(chan struct{})
+	s.release = make(chan struct{})
+	s.mu.Unlock()
+}
+
+// unblock releases the blocked operation.
+func (s *mapStore) unblock() {
+	close(s.release)
+}
+
+// pause blocks the operation if it has been selected with block.
+func (s *mapStore) pause(op string) {
+	s.mu.Lock()
+	blocked := s.blockOn == op
+	if blocked {
+		s.blockOn = ""
+	}
+	s.mu.Unlock()
+
+	if blocked {
+		s.started <- struct{}{}
+		<-s.release
+	}
+}
+
+func (s *mapStore) get(key string) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.data[key]
+	return v, ok
+}
+
+func (s *mapStore) setFail(fail bool) {
+	s.mu.Lock()
+	s.fail = fail
+	s.mu.Unlock()
+}
+
+func TestCache_StoreWriteThrough(t *testing.T) {
+	assert := assert.New(t)
+
+	store := newMapStore()
+	store.data["a"] = 1
+
+	c := NewWithStore[string, int](store, NoExpiration, 0, WithCapacity(2))
+
+	// Read-through on cache miss.
+	item, err := c.Get("a")
+	assert.NoError(err)
+	assert.Equal(1, item.Val())
+	assert.Equal(1, c.Count())
+	c.Get("a")
+	assert.Equal(int32(1), atomic.LoadInt32(&store.loads))
+
+	_, err = c.Get("missing")
+	assert.Error(err)
+
+	// Write-through on insertion and update.
+	err = c.Set("b", 2, DefaultExpiration)
+	assert.NoError(err)
+	v, ok := store.get("b")
+	assert.True(ok)
+	assert.Equal(2, v)
+
+	c.Update("b", 3, DefaultExpiration)
+	v, _ = store.get("b")
+	assert.Equal(3, v)
+
+	c.GetOrSet("c", 4, DefaultExpiration)
+	v, _ = store.get("c")
+	assert.Equal(4, v)
+
+	Increment(c, "c", 1)
+	v, _ = store.get("c")
+	assert.Equal(5, v)
+
+	// The items evicted because of the capacity limit are kept in the store.
+	_, err = c.Get("a")
+	assert.NoError(err)
+	assert.Equal(2, c.Count())
+	_, ok = store.get("b")
+	assert.True(ok)
+
+	// Deleting an item removes it from the store, even if it's not cached.
+	err = c.Delete("b")
+	assert.NoError(err)
+	_, ok = store.get("b")
+	assert.False(ok)
+
+	c.Set("d", 6, DefaultExpiration, WithTags("tag"))
+	n, err := c.InvalidateTag("tag")
+	assert.NoError(err)
+	assert.Equal(1, n)
+	_, ok = store.get("d")
+	assert.False(ok)
+
+	// The failed writes are not stored in the cache.
+	store.setFail(true)
+	err = c.Set("e", 7, DefaultExpiration)
+	assert.Error(err)
+	_, err = c.Get("e")
+	assert.Error(err)
+	store.setFail(false)
+
+	assert.NoError(c.Sync())
+	assert.NoError(c.Close())
+}
+
+func TestCache_StoreWriteBehind(t *testing.T) {
+	assert := assert.New(t)
+
+	store := newMapStore()
+	store.data["a"] = 1
+
+	c := NewWithStore[string, int](store, NoExpiration, 0, WithWriteBehind(time.Hour, 3))
+	c.Set("b", 2, DefaultExpiration)
+	c.Update("b", 3, DefaultExpiration)
+	c.Delete("a")
+
+	// The pending operations are not propagated yet.
+	v, _ := store.get("b")
+	assert.Equal(0, v)
+	_, ok := store.get("a")
+	assert.True(ok)
+
+	// A pending deletion is more recent than the store content.
+	_, err := c.Get("a")
+	assert.Error(err)
+
+	err = c.Sync()
+	assert.NoError(err)
+	v, _ = store.get("b")
+	assert.Equal(3, v)
+	_, ok = store.get("a")
+	assert.False(ok)
+
+	// Reaching the batch size triggers the flush.
+	c.Set("c", 1, DefaultExpiration)
+	c.Set("d", 2, DefaultExpiration)
+	c.Set("e", 3, DefaultExpiration)
+	assert.Eventually(func() bool {
+		_, ok := store.get("e")
+		return ok
+	}, time.Second, 5*time.Millisecond)
+
+	// The failed operations are retried on the next flush.
+	store.setFail(true)
+	c.Set("f", 4, DefaultExpiration)
+	err = c.Sync()
+	assert.Error(err)
+	store.setFail(false)
+
+	// Close flushes the pending operations.
+	err = c.Close()
+	assert.NoError(err)
+	v, _ = store.get("f")
+	assert.Equal(4, v)
+}
+
+func TestCache_StoreWriteBehindInterval(t *testing.T) {
+	assert := assert.New(t)
+
+	store := newMapStore()
+	c := NewWithStore[string, int](store, NoExpiration, 0, WithWriteBehind(5*time.Millisecond, 0))
+	defer c.Close()
+
+	c.Set("a", 1, DefaultExpiration)
+	assert.Eventually(func() bool {
+		_, ok := store.get("a")
+		return ok
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestCache_StoreFlushInFlight(t *testing.T) {
+	assert := assert.New(t)
+
+	store := newMapStore()
+	store.data["a"] = 1
+
+	c := NewWithStore[string, int](store, NoExpiration, 0, WithWriteBehind(time.Hour, 0))
+	defer c.Close()
+
+	_, err := c.Get("a")
+	assert.NoError(err)
+	assert.NoError(c.Delete("a"))
+
+	store.block("delete")
+	done := make(chan error)
+	go func() {
+		done <- c.Sync()
+	}()
+	<-store.started
+
+	// The deletion is being flushed, so the item should not be loaded from the store.
+	_, err = c.Get("a")
+	assert.Error(err)
+
+	store.unblock()
+	assert.NoError(<-done)
+
+	_, err = c.Get("a")
+	assert.Error(err)
+	_, ok := store.get("a")
+	assert.False(ok)
+}
+
+// waiting reports whether n goroutines are holding or waiting for the lock of the key.
+func waiting[T comparable, V any](c *Cache[T, V], key T, n int) func() bool {
+	return func() bool {
+		c.keys.mu.Lock()
+		defer c.keys.mu.Unlock()
+
+		m, ok := c.keys.locks[key]
+		return ok && m.refs == n
+	}
+}
+
+func TestCache_StoreReadThroughUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	store := newMapStore()
+	store.data["a"] = 1
+	c := NewWithStore[string, int](store, NoExpiration, 0)
+
+	// The read-through load has fetched the old value from the store, when the update comes in.
+	store.block("load")
+	loaded := make(chan struct{})
+	go func() {
+		c.Get("a")
+		close(loaded)
+	}()
+	<-store.started
+
+	updated := make(chan error)
+	go func() {
+		updated <- c.Update("a", 2, DefaultExpiration)
+	}()
+	assert.Eventually(waiting(c, "a", 2), time.Second, time.Millisecond)
+	store.unblock()
+	<-loaded
+	assert.NoError(<-updated)
+
+	item, err := c.Get("a")
+	assert.NoError(err)
+	assert.Equal(2, item.Val())
+	v, _ := store.get("a")
+	assert.Equal(2, v)
+}
+
+func TestCache_StoreConcurrentWrites(t *testing.T) {
+	assert := assert.New(t)
+
+	store := newMapStore()
+	c := NewWithStore[string, int](store, NoExpiration, 0)
+
+	for _, tc := range []struct {
+		update func(val int) error
+		want   int
+	}{
+		{func(val int) error { return c.Update("a", val, DefaultExpiration) }, 2},
+		{func(val int) error {
+			_, err := Increment(c, "a", val)
+			return err
+		}, 3},
+	} {
+		c.Update("a", 0, DefaultExpiration)
+
+		// The first write is blocked after saving its value into the store.
+		store.block("save")
+		first := make(chan error)
+		go func() {
+			first <- tc.update(1)
+		}()
+		<-store.started
+
+		second := make(chan error)
+		go func() {
+			second <- tc.update(2)
+		}()
+		assert.Eventually(waiting(c, "a", 2), time.Second, time.Millisecond)
+		store.unblock()
+		assert.NoError(<-first)
+		assert.NoError(<-second)
+
+		item, err := c.Get("a")
+		assert.NoError(err)
+		assert.Equal(tc.want, item.Val())
+		v, _ := store.get("a")
+		assert.Equal(tc.want, v)
+	}
+}
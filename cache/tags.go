@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"fmt"
+
+	"go.uber.org/multierr"
+)
+
+// WithTags attaches tags to the item, which makes possible to remove all the items
+// having the same tag at once using InvalidateTag.
+func WithTags(tags ...string) ItemOption {
+	return func(o *itemOptions) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// InvalidateTag removes all the items having the provided tag and returns the number of removed items.
+// The returned error combines the errors of the eviction callback invoked for each removed item.
+func (c *Cache[T, V]) InvalidateTag(tag string) (int, error) {
+	if c.closed.Load() {
+		return 0, ErrorClosed
+	}
+
+	c.mu.Lock()
+	keys := make([]T, 0, len(c.tags[tag]))
+	for k := range c.tags[tag] {
+		keys = append(keys, k)
+	}
+	evicted := c.remove(keys)
+	c.mu.Unlock()
+
+	return len(keys), multierr.Append(c.eraseAll(keys), c.notify(evicted))
+}
+
+// DeletePrefix removes all the items whose key starts with the provided prefix and returns the number
+// of removed items. It's supported only by the caches having string based keys, otherwise it returns an error.
+// The keys of these caches are indexed on insertion, so the cost of the operation is proportional
+// with the number of matching items. The returned error combines the errors of the eviction callback
+// invoked for each removed item.
+func (c *Cache[T, V]) DeletePrefix(prefix string) (int, error) {
+	var key T
+
+	if c.closed.Load() {
+		return 0, ErrorClosed
+	}
+	if c.prefixes == nil {
+		return 0, fmt.Errorf("the cache keys of type %T do not support prefix deletion", key)
+	}
+
+	c.mu.Lock()
+	keys := c.prefixes.collect(prefix)
+	evicted := c.remove(keys)
+	c.mu.Unlock()
+
+	return len(keys), multierr.Append(c.eraseAll(keys), c.notify(evicted))
+}
+
+// remove deletes the items under the provided keys and returns the items passed to the eviction callback.
+// It must be called with the write lock held.
+func (c *cache[T, V]) remove(keys []T) []evictedItem[T, V] {
+	var evicted []evictedItem[T, V]
+
+	for _, k := range keys {
+		evicted = c.collect(evicted, k, Deleted)
+		c.delete(k)
+	}
+
+	return evicted
+}
+
+// eraseAll propagates the deletion of the keys into the backing store.
+func (c *cache[T, V]) eraseAll(keys []T) error {
+	var err error
+
+	for _, k := range keys {
+		err = multierr.Append(err, c.erase(k))
+	}
+
+	return err
+}
+
+// index adds the item into the tag and prefix indexes. It must be called with the write lock held.
+func (c *cache[T, V]) index(key T, item *Item[V]) {
+	for _, tag := range item.tags {
+		if c.tags == nil {
+			c.tags = make(map[string]map[T]struct{})
+		}
+		if _, ok := c.tags[tag]; !ok {
+			c.tags[tag] = make(map[T]struct{})
+		}
+		c.tags[tag][key] = struct{}{}
+	}
+	if c.prefixes != nil {
+		c.prefixes.add(keyString(key), key)
+	}
+}
+
+// unindex removes the item from the tag and prefix indexes. It must be called with the write lock held.
+func (c *cache[T, V]) unindex(key T, item *Item[V]) {
+	for _, tag := range item.tags {
+		if keys, ok := c.tags[tag]; ok {
+			delete(keys, key)
+			if len(keys) == 0 {
+				delete(c.tags, tag)
+			}
+		}
+	}
+	if c.prefixes != nil {
+		c.prefixes.remove(keyString(key))
+	}
+}
+
+// InvalidateTag removes the items having the provided tag from all the shards.
+func (s *Sharded[T, V]) InvalidateTag(tag string) (int, error) {
+	var (
+		n   int
+		err error
+	)
+
+	for _, c := range s.shards {
+		cnt, e := c.InvalidateTag(tag)
+		n += cnt
+		err = multierr.Append(err, e)
+	}
+
+	return n, err
+}
+
+// DeletePrefix removes the items whose key starts with the provided prefix from all the shards.
+func (s *Sharded[T, V]) DeletePrefix(prefix string) (int, error) {
+	var (
+		n   int
+		err error
+		key T
+	)
+
+	if !stringKeys[T]() {
+		return 0, fmt.Errorf("the cache keys of type %T do not support prefix deletion", key)
+	}
+
+	for _, c := range s.shards {
+		cnt, e := c.DeletePrefix(prefix)
+		n += cnt
+		err = multierr.Append(err, e)
+	}
+
+	return n, err
+}
@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_InvalidateTag(t *testing.T) {
+	assert := assert.New(t)
+
+	var evicted []string
+	c := New[string, int](NoExpiration, 0)
+	c.OnEvicted(func(key string, val int, reason EvictionReason) error {
+		assert.Equal(Deleted, reason)
+		evicted = append(evicted, key)
+		return nil
+	})
+	c.Set("a", 1, DefaultExpiration, WithTags("tenant1"))
+	c.Set("b", 2, DefaultExpiration, WithTags("tenant1", "users"))
+	c.Set("c", 3, DefaultExpiration, WithTags("tenant2", "users"))
+	c.Set("d", 4, DefaultExpiration)
+
+	n, err := c.InvalidateTag("tenant1")
+	assert.NoError(err)
+	assert.Equal(2, n)
+	assert.ElementsMatch([]string{"a", "b"}, evicted)
+	assert.Equal(2, c.Count())
+
+	// The removed items are dropped from the other tags' index too.
+	n, err = c.InvalidateTag("users")
+	assert.NoError(err)
+	assert.Equal(1, n)
+	assert.Empty(c.tags)
+
+	n, err = c.InvalidateTag("missing")
+	assert.NoError(err)
+	assert.Equal(0, n)
+
+	// Replacing an item replaces its tags too.
+	c.Set("e", 5, DefaultExpiration, WithTags("tenant3"))
+	c.Update("e", 6, DefaultExpiration, WithTags("tenant4"))
+	n, _ = c.InvalidateTag("tenant3")
+	assert.Equal(0, n)
+	n, _ = c.InvalidateTag("tenant4")
+	assert.Equal(1, n)
+
+	c.OnEvicted(nil)
+	c.Set("f", 6, DefaultExpiration, WithTags("tenant5"))
+	c.Flush()
+	n, _ = c.InvalidateTag("tenant5")
+	assert.Equal(0, n)
+	assert.Equal(0, c.Count())
+
+	// The tags are preserved by the snapshots.
+	c.Set("g", 7, DefaultExpiration, WithTags("tenant6"))
+	var buf bytes.Buffer
+	c.Save(&buf, Gob)
+	c2 := New[string, int](NoExpiration, 0)
+	c2.Load(&buf, Gob)
+	n, _ = c2.InvalidateTag("tenant6")
+	assert.Equal(1, n)
+}
+
+func TestCache_DeletePrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New[string, int](NoExpiration, 0, WithCapacity(10))
+	c.Set("tenant1:a", 1, DefaultExpiration)
+	c.Set("tenant1:b", 2, DefaultExpiration)
+	c.Set("tenant10:a", 3, DefaultExpiration)
+	c.Set("tenant2:a", 4, DefaultExpiration)
+
+	// The keys are indexed on insertion.
+	assert.Len(c.prefixes.collect("tenant1"), 3)
+
+	n, err := c.DeletePrefix("tenant1:")
+	assert.NoError(err)
+	assert.Equal(2, n)
+	assert.Equal(2, c.Count())
+
+	// The index is maintained on insertion and deletion.
+	c.Set("tenant1:c", 5, DefaultExpiration)
+	c.Delete("tenant2:a")
+	n, err = c.DeletePrefix("tenant2")
+	assert.NoError(err)
+	assert.Equal(0, n)
+
+	n, err = c.DeletePrefix("tenant1")
+	assert.NoError(err)
+	assert.Equal(2, n)
+	assert.Equal(0, c.Count())
+	assert.Empty(c.prefixes.root.children)
+
+	for i := 0; i < 15; i++ {
+		c.Set(fmt.Sprintf("key%d", i), i, DefaultExpiration)
+	}
+	n, _ = c.DeletePrefix("key")
+	assert.Equal(10, n)
+
+	c.Set("foo", 1, DefaultExpiration)
+	c.Flush()
+	n, _ = c.DeletePrefix("")
+	assert.Equal(0, n)
+
+	type name string
+	c1 := New[name, int](NoExpiration, 0)
+	c1.Set("foo", 1, DefaultExpiration)
+	c1.Set("bar", 2, DefaultExpiration)
+	n, err = c1.DeletePrefix("")
+	assert.NoError(err)
+	assert.Equal(2, n)
+
+	c2 := New[int, int](NoExpiration, 0)
+	assert.Nil(c2.prefixes)
+	_, err = c2.DeletePrefix("1")
+	assert.Error(err)
+}
+
+func TestSharded_InvalidateTag(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewSharded[string, int](4, NoExpiration, 0)
+	for i := 0; i < 20; i++ {
+		c.Set(fmt.Sprintf("tenant%d:%d", i%2, i), i, DefaultExpiration, WithTags(fmt.Sprintf("tag%d", i%2)))
+	}
+	n, err := c.InvalidateTag("tag0")
+	assert.NoError(err)
+	assert.Equal(10, n)
+	n, err = c.DeletePrefix("tenant1:")
+	assert.NoError(err)
+	assert.Equal(10, n)
+	assert.Equal(0, c.Count())
+
+	c2 := NewSharded[int, int](4, NoExpiration, 0)
+	_, err = c2.DeletePrefix("1")
+	assert.Error(err)
+
+	c.Close()
+	_, err = c.InvalidateTag("tag0")
+	assert.ErrorIs(err, ErrorClosed)
+	_, err = c.DeletePrefix("tenant")
+	assert.ErrorIs(err, ErrorClosed)
+}
@@ -36,6 +36,7 @@ func After[V constraints.Signed](n *V, fn func()) {
 // Before creates a function wrapper that memoizes its return value.
 // From the nth call onwards, the memoized result of the last invocation is returned immediately
 // instead of invoking function again. So the wrapper will invoke function at most n-1 times.
+// The result is stored in the cache under the reserved "func" key.
 func Before[S ~string, T any, V constraints.Signed](n *V, c *cache.Cache[S, T], fn func() T) T {
 	var memo *cache.Item[T]
 	*n-- // decrease the n as pointer receiver
@@ -53,6 +54,7 @@ func Before[S ~string, T any, V constraints.Signed](n *V, c *cache.Cache[S, T],
 // Once is like Before, but it's invoked only once.
 // Repeated calls to the modified function will have no effect
 // and the function invocation is returned from the cache.
+// Like in case of Before, the result is stored under the reserved "func" key.
 func Once[S ~string, T comparable, V constraints.Signed](c *cache.Cache[S, T], fn func() T) T {
 	memo, _ := c.Get("func")
 	if memo == nil {
@@ -170,23 +170,23 @@ func Example_before() {
 		// In this case the cache item "func" should be empty.
 		if n > 0 {
 			val, _ := c.Get("func")
-			fmt.Println(val)
+			fmt.Println(val == nil)
 			fmt.Println(res)
 		}
 		if n <= 0 {
 			// Here the callback function is served from the cache.
 			val, _ := c.Get("func")
-			fmt.Println(val)
+			fmt.Println(val.Val())
 			fmt.Println(res)
 		}
 	})
 
 	// Output:
-	// <nil>
+	// true
 	// 2
-	// <nil>
+	// true
 	// 1
-	// &{0 0}
+	// 0
 	// 0
 }
 
@@ -592,3 +592,15 @@ func TestFunc_ThrottlePings(t *testing.T) {
 
 	assert.Equal(0, count)
 }
+
+func TestFunc_BeforeOnceStringKey(t *testing.T) {
+	assert := assert.New(t)
+
+	type key string
+	c := cache.New[key, int](cache.DefaultExpiration, cache.NoExpiration)
+	assert.Equal(1, Once[key, int, int](c, func() int { return 1 }))
+	assert.Equal(1, Once[key, int, int](c, func() int { return 2 }))
+	item, err := c.Get("func")
+	assert.NoError(err)
+	assert.Equal(1, item.Val())
+}
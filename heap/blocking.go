@@ -0,0 +1,209 @@
+package heap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/esimov/gogu"
+)
+
+// ErrorClosed is returned when pushing into a closed blocking queue,
+// or when popping from a closed and drained one.
+var ErrorClosed = fmt.Errorf("queue is closed")
+
+// BlockingQueue is a concurrent priority queue built on top of the binary heap,
+// which can be used for building worker pools. The consumers block until an element becomes available,
+// while the producers block if the queue has a capacity bound and it's full.
+// The comparison function defines the order in which the elements are popped, like in case of the heap.
+type BlockingQueue[T comparable] struct {
+	mu     sync.Mutex
+	heap   *Heap[T]
+	cap    int
+	closed bool
+	// signal is closed and replaced each time the queue changes, waking up all the waiting goroutines.
+	signal chan struct{}
+}
+
+// NewBlocking creates a new blocking priority queue. A capacity greater than zero limits
+// the number of queued elements, the producers being blocked until the consumers make room.
+// Otherwise the queue is unbounded.
+func NewBlocking[T comparable](capacity int, comp gogu.CompFn[T]) *BlockingQueue[T] {
+	return &BlockingQueue[T]{
+		heap:   NewHeap(comp),
+		cap:    capacity,
+		signal: make(chan struct{}),
+	}
+}
+
+// Size returns the number of the queued elements.
+func (q *BlockingQueue[T]) Size() int {
+	return q.heap.Size()
+}
+
+// IsEmpty checks if the queue is empty or not.
+func (q *BlockingQueue[T]) IsEmpty() bool {
+	return q.heap.Size() == 0
+}
+
+// Peek returns the first element of the queue without removing it.
+func (q *BlockingQueue[T]) Peek() T {
+	return q.heap.Peek()
+}
+
+// PushWait inserts a new element into the queue. If the queue is full, it blocks until
+// an element is popped, the queue is closed or the context is cancelled.
+func (q *BlockingQueue[T]) PushWait(ctx context.Context, val T) error {
+	q.mu.Lock()
+	for {
+		if q.closed {
+			q.mu.Unlock()
+			return ErrorClosed
+		}
+		if q.cap <= 0 || q.heap.Size() < q.cap {
+			break
+		}
+		if err := q.wait(ctx); err != nil {
+			return err
+		}
+	}
+	q.heap.Push(val)
+	q.broadcast()
+	q.mu.Unlock()
+
+	return nil
+}
+
+// TryPush inserts a new element into the queue without blocking.
+// It returns false if the queue is full and an error if the queue is closed.
+func (q *BlockingQueue[T]) TryPush(val T) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return false, ErrorClosed
+	}
+	if q.cap > 0 && q.heap.Size() >= q.cap {
+		return false, nil
+	}
+	q.heap.Push(val)
+	q.broadcast()
+
+	return true, nil
+}
+
+// PopWait removes and returns the first element of the queue. If the queue is empty, it blocks until
+// an element is pushed, the queue is closed or the context is cancelled.
+// The elements of a closed queue are still returned, until the queue is drained.
+func (q *BlockingQueue[T]) PopWait(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for {
+		if q.heap.Size() > 0 {
+			break
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var t T
+			return t, ErrorClosed
+		}
+		if err := q.wait(ctx); err != nil {
+			var t T
+			return t, err
+		}
+	}
+	val := q.heap.Pop()
+	q.broadcast()
+	q.mu.Unlock()
+
+	return val, nil
+}
+
+// TryPop removes and returns the first element of the queue without blocking.
+// The returned boolean is false if the queue is empty.
+func (q *BlockingQueue[T]) TryPop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.heap.Size() == 0 {
+		var t T
+		return t, false
+	}
+	val := q.heap.Pop()
+	q.broadcast()
+
+	return val, true
+}
+
+// Stream returns a channel delivering the elements of the queue in priority order.
+// The channel is closed once the queue is closed and drained, or the context is cancelled.
+// An element popped while the context is being cancelled is pushed back into the queue.
+func (q *BlockingQueue[T]) Stream(ctx context.Context) <-chan T {
+	ch := make(chan T)
+
+	go func() {
+		defer close(ch)
+		for {
+			val, err := q.PopWait(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- val:
+			case <-ctx.Done():
+				q.requeue(val)
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// Close closes the queue and wakes up all the waiting goroutines. The blocked producers
+// and the further pushes return ErrorClosed, while the consumers can still pop the queued elements.
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		q.broadcast()
+	}
+}
+
+// IsClosed checks if the queue has been closed.
+func (q *BlockingQueue[T]) IsClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.closed
+}
+
+// requeue pushes back an element, ignoring the capacity and the closed state of the queue.
+func (q *BlockingQueue[T]) requeue(val T) {
+	q.mu.Lock()
+	q.heap.Push(val)
+	q.broadcast()
+	q.mu.Unlock()
+}
+
+// wait releases the lock and waits for the next change of the queue or the context cancellation.
+// The lock is held again on return, except when the context has been cancelled.
+func (q *BlockingQueue[T]) wait(ctx context.Context) error {
+	signal := q.signal
+	q.mu.Unlock()
+
+	select {
+	case <-signal:
+		q.mu.Lock()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// broadcast wakes up the waiting goroutines. It must be called with the lock held.
+func (q *BlockingQueue[T]) broadcast() {
+	close(q.signal)
+	q.signal = make(chan struct{})
+}
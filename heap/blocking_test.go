@@ -0,0 +1,207 @@
+package heap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	assert := assert.New(t)
+
+	q := NewBlocking(0, func(a, b int) bool { return a < b })
+	assert.True(q.IsEmpty())
+	_, ok := q.TryPop()
+	assert.False(ok)
+
+	ctx := context.Background()
+	for _, v := range []int{5, 2, 8, 1} {
+		assert.NoError(q.PushWait(ctx, v))
+	}
+	assert.Equal(4, q.Size())
+	assert.Equal(1, q.Peek())
+
+	val, err := q.PopWait(ctx)
+	assert.NoError(err)
+	assert.Equal(1, val)
+	val, ok = q.TryPop()
+	assert.True(ok)
+	assert.Equal(2, val)
+
+	// PopWait blocks until an element is pushed.
+	go func() {
+		<-time.After(10 * time.Millisecond)
+		q.PushWait(ctx, 0)
+	}()
+	q.TryPop()
+	q.TryPop()
+	val, err = q.PopWait(ctx)
+	assert.NoError(err)
+	assert.Equal(0, val)
+
+	// PopWait returns once the context is cancelled.
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = q.PopWait(cctx)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestBlockingQueue_Capacity(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	q := NewBlocking(2, func(a, b int) bool { return a < b })
+	ok, err := q.TryPush(3)
+	assert.True(ok)
+	assert.NoError(err)
+	assert.NoError(q.PushWait(ctx, 1))
+	ok, err = q.TryPush(2)
+	assert.False(ok)
+	assert.NoError(err)
+
+	// PushWait blocks until there is room in the queue.
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(q.PushWait(cctx, 2), context.DeadlineExceeded)
+
+	done := make(chan error)
+	go func() {
+		done <- q.PushWait(ctx, 2)
+	}()
+	<-time.After(10 * time.Millisecond)
+	val, _ := q.PopWait(ctx)
+	assert.Equal(1, val)
+	assert.NoError(<-done)
+	assert.Equal(2, q.Size())
+	assert.Equal(2, q.Peek())
+}
+
+func TestBlockingQueue_Close(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	q := NewBlocking(1, func(a, b int) bool { return a < b })
+	q.PushWait(ctx, 1)
+
+	// The blocked producers are woken up.
+	done := make(chan error)
+	go func() {
+		done <- q.PushWait(ctx, 2)
+	}()
+	<-time.After(10 * time.Millisecond)
+	q.Close()
+	assert.ErrorIs(<-done, ErrorClosed)
+	assert.True(q.IsClosed())
+	_, err := q.TryPush(3)
+	assert.ErrorIs(err, ErrorClosed)
+
+	// The queued elements are drained after closing.
+	val, err := q.PopWait(ctx)
+	assert.NoError(err)
+	assert.Equal(1, val)
+	_, err = q.PopWait(ctx)
+	assert.ErrorIs(err, ErrorClosed)
+
+	// The blocked consumers are woken up.
+	q = NewBlocking(0, func(a, b int) bool { return a < b })
+	go func() {
+		_, err := q.PopWait(ctx)
+		done <- err
+	}()
+	<-time.After(10 * time.Millisecond)
+	q.Close()
+	assert.ErrorIs(<-done, ErrorClosed)
+	q.Close()
+}
+
+func TestBlockingQueue_Stream(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	q := NewBlocking(0, func(a, b int) bool { return a > b })
+	for i := 0; i < 5; i++ {
+		q.PushWait(ctx, i)
+	}
+	q.Close()
+
+	var result []int
+	for v := range q.Stream(ctx) {
+		result = append(result, v)
+	}
+	assert.Equal([]int{4, 3, 2, 1, 0}, result)
+
+	q = NewBlocking(0, func(a, b int) bool { return a > b })
+	cctx, cancel := context.WithCancel(ctx)
+	ch := q.Stream(cctx)
+	q.PushWait(ctx, 1)
+	assert.Equal(1, <-ch)
+	cancel()
+	_, ok := <-ch
+	assert.False(ok)
+}
+
+func TestBlockingQueue_Workers(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	q := NewBlocking(5, func(a, b int) bool { return a < b })
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		result []int
+	)
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				val, err := q.PopWait(ctx)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				result = append(result, val)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(q.PushWait(ctx, i))
+	}
+	q.Close()
+	wg.Wait()
+
+	sort.Ints(result)
+	assert.Len(result, 100)
+	for i := range result {
+		assert.Equal(i, result[i])
+	}
+}
+
+func Example_blockingQueue() {
+	ctx := context.Background()
+	q := NewBlocking(10, func(a, b string) bool { return a < b })
+
+	done := make(chan struct{})
+	go func() {
+		for job := range q.Stream(ctx) {
+			fmt.Println(job)
+		}
+		close(done)
+	}()
+
+	q.PushWait(ctx, "job")
+	<-time.After(10 * time.Millisecond)
+	q.Close()
+	<-done
+
+	// Output:
+	// job
+}
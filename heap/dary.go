@@ -0,0 +1,179 @@
+package heap
+
+import (
+	"sync"
+
+	"github.com/esimov/gogu"
+)
+
+// DaryHeap is a thread-safe implementation of the d-ary heap, a generalization of the binary heap
+// in which each node has d children instead of two. Because of its lower height, a heap with a higher arity
+// makes less moves on insertion and it's more cache friendly, at the expense of more comparisons on removal.
+type DaryHeap[T any] struct {
+	mu   *sync.RWMutex
+	comp gogu.CompFn[T]
+	data []T
+	d    int
+}
+
+// NewDary creates a new d-ary heap with the provided arity and comparison function.
+// An arity lower than 2 falls back to a binary heap.
+func NewDary[T any](d int, comp gogu.CompFn[T]) *DaryHeap[T] {
+	if d < 2 {
+		d = 2
+	}
+	return &DaryHeap[T]{
+		mu:   new(sync.RWMutex),
+		comp: comp,
+		data: make([]T, 0),
+		d:    d,
+	}
+}
+
+// Size returns the heap size.
+func (h *DaryHeap[T]) Size() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.data)
+}
+
+// IsEmpty checks if the heap is empty or not.
+func (h *DaryHeap[T]) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Clear removes all the elements from the heap.
+func (h *DaryHeap[T]) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.data = h.data[:0]
+}
+
+// Peek returns the first element of the heap.
+// This can be the minimum or maximum value depending on the heap type.
+func (h *DaryHeap[T]) Peek() T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.data) == 0 {
+		var t T
+		return t
+	}
+	return h.data[0]
+}
+
+// GetValues returns the heap values.
+func (h *DaryHeap[T]) GetValues() []T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.data
+}
+
+// Push inserts new elements into the heap and moves them up to their correct position.
+func (h *DaryHeap[T]) Push(val ...T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, v := range val {
+		h.data = append(h.data, v)
+		h.moveUp(len(h.data) - 1)
+	}
+}
+
+// Pop removes the first element from the heap and reorders the existing elements.
+// The removed element is the minimum or maximum depending on the heap type.
+func (h *DaryHeap[T]) Pop() T {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var val T
+	n := len(h.data)
+	if n == 0 {
+		return val
+	}
+	val = h.data[0]
+	h.data[0] = h.data[n-1]
+	h.data = h.data[:n-1]
+	h.moveDown(0)
+
+	return val
+}
+
+// Merge joins two heaps into a new one preserving the original ones.
+// The new heap has the arity and the comparison function of the receiver.
+func (h *DaryHeap[T]) Merge(h2 *DaryHeap[T]) *DaryHeap[T] {
+	data := append(h.values(), h2.values()...)
+
+	return h.heapify(data)
+}
+
+// Meld merges two heaps into a new one containing all the elements of both and destroying the original ones.
+func (h *DaryHeap[T]) Meld(h2 *DaryHeap[T]) *DaryHeap[T] {
+	data := append(h.detach(), h2.detach()...)
+
+	return h.heapify(data)
+}
+
+// values returns a copy of the heap values.
+func (h *DaryHeap[T]) values() []T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return append([]T(nil), h.data...)
+}
+
+// detach removes and returns the heap values.
+func (h *DaryHeap[T]) detach() []T {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	data := h.data
+	h.data = make([]T, 0)
+
+	return data
+}
+
+// heapify builds a new heap from the provided values in linear time.
+func (h *DaryHeap[T]) heapify(data []T) *DaryHeap[T] {
+	newHeap := NewDary(h.d, h.comp)
+	newHeap.data = data
+	for i := (len(data) - 2) / h.d; i >= 0; i-- {
+		newHeap.moveDown(i)
+	}
+
+	return newHeap
+}
+
+// moveDown moves the element at index i down to its correct position in the heap.
+func (h *DaryHeap[T]) moveDown(i int) {
+	n := len(h.data)
+	for {
+		current := i
+		first := h.d*i + 1
+		for c := first; c < first+h.d && c < n; c++ {
+			if h.comp(h.data[c], h.data[current]) {
+				current = c
+			}
+		}
+		if current == i {
+			return
+		}
+		swap(h.data, i, current)
+		i = current
+	}
+}
+
+// moveUp moves the element at index i up to its correct position in the heap.
+func (h *DaryHeap[T]) moveUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / h.d
+		if !h.comp(h.data[i], h.data[parent]) {
+			return
+		}
+		swap(h.data, i, parent)
+		i = parent
+	}
+}
@@ -0,0 +1,99 @@
+package heap
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaryHeap(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, d := range []int{0, 2, 3, 4, 8} {
+		h := NewDary(d, func(a, b int) bool { return a < b })
+		assert.True(h.IsEmpty())
+		assert.Equal(0, h.Pop())
+		assert.Equal(0, h.Peek())
+
+		input := rand.Perm(100)
+		h.Push(input...)
+		assert.Equal(100, h.Size())
+		assert.Equal(0, h.Peek())
+
+		for i := 0; i < 100; i++ {
+			assert.Equal(i, h.Pop())
+		}
+		assert.True(h.IsEmpty())
+
+		h.Push(1, 2, 3)
+		h.Clear()
+		assert.Equal(0, h.Size())
+	}
+}
+
+func TestDaryHeap_Merge(t *testing.T) {
+	assert := assert.New(t)
+
+	h1 := NewDary(4, func(a, b int) bool { return a > b })
+	h1.Push(1, 5, 9, 3)
+	h2 := NewDary(3, func(a, b int) bool { return a > b })
+	h2.Push(4, 8, 2)
+
+	h := h1.Merge(h2)
+	assert.Equal(4, h1.Size())
+	assert.Equal(3, h2.Size())
+	assert.Equal(7, h.Size())
+
+	h = h1.Meld(h2)
+	assert.True(h1.IsEmpty())
+	assert.True(h2.IsEmpty())
+
+	var result []int
+	for !h.IsEmpty() {
+		result = append(result, h.Pop())
+	}
+	expected := []int{1, 5, 9, 3, 4, 8, 2}
+	sort.Sort(sort.Reverse(sort.IntSlice(expected)))
+	assert.Equal(expected, result)
+}
+
+func Example_daryHeap() {
+	h := NewDary(4, func(a, b int) bool { return a < b })
+	h.Push(7, 3, 9, 1, 5)
+
+	for !h.IsEmpty() {
+		fmt.Print(h.Pop(), " ")
+	}
+
+	// Output:
+	// 1 3 5 7 9
+}
+
+func BenchmarkHeap_PushPop(b *testing.B) {
+	comp := func(a, b int) bool { return a < b }
+	input := rand.Perm(10000)
+
+	impls := []struct {
+		name string
+		heap func() Interface[int]
+	}{
+		{"Binary", func() Interface[int] { return NewHeap(comp) }},
+		{"Dary-4", func() Interface[int] { return NewDary(4, comp) }},
+		{"Dary-8", func() Interface[int] { return NewDary(8, comp) }},
+		{"Pairing", func() Interface[int] { return NewPairing(comp) }},
+	}
+	for _, impl := range impls {
+		b.Run(impl.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h := impl.heap()
+				h.Push(input...)
+				for !h.IsEmpty() {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
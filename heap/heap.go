@@ -14,10 +14,36 @@ import (
 	"github.com/esimov/gogu"
 )
 
+// Interface is the common interface implemented by the heaps of this package,
+// which makes possible to swap the heap implementations.
+type Interface[T any] interface {
+	// Push inserts new elements into the heap.
+	Push(val ...T)
+	// Pop removes and returns the first element of the heap, or the zero value if the heap is empty.
+	Pop() T
+	// Peek returns the first element of the heap without removing it.
+	Peek() T
+	// Size returns the number of elements stored in the heap.
+	Size() int
+	// IsEmpty checks if the heap is empty or not.
+	IsEmpty() bool
+	// Clear removes all the elements from the heap.
+	Clear()
+}
+
+// Merger is implemented by the heaps which can be joined with another heap of the same type H.
+// Merge preserves the original heaps, while Meld consumes them.
+type Merger[T any, H any] interface {
+	Interface[T]
+	Merge(H) H
+	Meld(H) H
+}
+
 type Heap[T comparable] struct {
 	mu   *sync.RWMutex
 	comp gogu.CompFn[T]
 	data []T
+	cap  int
 }
 
 // NewHeap creates a new heap data structure having two components:
@@ -31,6 +57,22 @@ func NewHeap[T comparable](comp gogu.CompFn[T]) *Heap[T] {
 	}
 }
 
+// NewBounded creates a new heap holding at most capacity elements. Once the heap is full,
+// the first element of the heap, which is the minimum or maximum value depending on the heap type,
+// is discarded on each push, unless the pushed element would take its place. This means that a bounded min heap
+// retains the largest elements pushed into it, while a bounded max heap retains the smallest ones.
+func NewBounded[T comparable](capacity int, comp gogu.CompFn[T]) *Heap[T] {
+	h := NewHeap(comp)
+	h.cap = capacity
+
+	return h
+}
+
+// Capacity returns the maximum number of elements of a bounded heap, or 0 if the heap is unbounded.
+func (h *Heap[T]) Capacity() int {
+	return h.cap
+}
+
 // Size returns the heap size.
 func (h *Heap[T]) Size() int {
 	h.mu.RLock()
@@ -64,8 +106,7 @@ func (h *Heap[T]) Peek() T {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	if h.Size() == 0 {
-		h.mu.RUnlock()
+	if len(h.data) == 0 {
 		var t T
 		return t
 	}
@@ -85,6 +126,11 @@ func (h *Heap[T]) GetValues() []T {
 // the existing elements in ascending or descending order, depending on the heap type.
 func (h *Heap[T]) Push(val ...T) {
 	for _, v := range val {
+		if h.cap > 0 && h.Size() >= h.cap {
+			h.replaceTop(v)
+			continue
+		}
+
 		h.mu.Lock()
 		h.data = append(h.data, v)
 		h.mu.Unlock()
@@ -93,6 +139,21 @@ func (h *Heap[T]) Push(val ...T) {
 	}
 }
 
+// replaceTop replaces the first element of a full bounded heap with the new value,
+// in case the new value is not positioned before the first element by the comparison function.
+func (h *Heap[T]) replaceTop(val T) {
+	h.mu.Lock()
+	replace := h.comp(h.data[0], val)
+	if replace {
+		h.data[0] = val
+	}
+	h.mu.Unlock()
+
+	if replace {
+		h.moveDown(h.Size(), 0)
+	}
+}
+
 // Pop removes the first element from the heap and reorder the existing elements.
 // The removed element is the minimum or maximum depending on the heap type.
 func (h *Heap[T]) Pop() T {
@@ -183,8 +244,9 @@ func FromSlice[T comparable](data []T, comp gogu.CompFn[T]) *Heap[T] {
 }
 
 // Merge joins two heaps into a new one preserving the original ones.
+// The new heap inherits the capacity of the receiver.
 func (h *Heap[T]) Merge(h2 *Heap[T]) *Heap[T] {
-	newHeap := NewHeap(h.comp)
+	newHeap := NewBounded(h.cap, h.comp)
 
 	for i := 0; i < h.Size(); i++ {
 		newHeap.Push(h.data[i])
@@ -199,8 +261,9 @@ func (h *Heap[T]) Merge(h2 *Heap[T]) *Heap[T] {
 
 // Meld merge two heaps into a new one containing all the
 // elements of both and destroying the original ones.
+// The new heap inherits the capacity of the receiver.
 func (h *Heap[T]) Meld(h2 *Heap[T]) *Heap[T] {
-	newHeap := NewHeap(h.comp)
+	newHeap := NewBounded(h.cap, h.comp)
 
 	for i := 0; i < h.Size(); i++ {
 		newHeap.Push(h.data[i])
@@ -342,3 +342,31 @@ func TestHeap_Concurrency(t *testing.T) {
 	heap.Clear()
 	assert.Empty(heap.Size())
 }
+
+func TestHeap_Interface(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		_ Merger[int, *Heap[int]]        = (*Heap[int])(nil)
+		_ Merger[int, *DaryHeap[int]]    = (*DaryHeap[int])(nil)
+		_ Merger[int, *PairingHeap[int]] = (*PairingHeap[int])(nil)
+	)
+
+	comp := func(a, b int) bool { return a < b }
+	heaps := []Interface[int]{NewHeap(comp), NewDary(3, comp), NewPairing(comp)}
+	for _, h := range heaps {
+		assert.True(h.IsEmpty())
+		assert.Equal(0, h.Peek())
+		assert.Equal(0, h.Pop())
+
+		h.Push(4, 1, 3, 2)
+		assert.Equal(4, h.Size())
+		assert.Equal(1, h.Peek())
+		for i := 1; i <= 4; i++ {
+			assert.Equal(i, h.Pop())
+		}
+		h.Push(1)
+		h.Clear()
+		assert.True(h.IsEmpty())
+	}
+}
@@ -0,0 +1,227 @@
+package heap
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/esimov/gogu"
+)
+
+// indexedItem is a heap element holding the key and its priority value.
+type indexedItem[K comparable, V any] struct {
+	key K
+	val V
+}
+
+// IndexedHeap is a thread-safe indexed priority queue. Each element is identified by a unique key
+// and its position in the heap is tracked, which makes possible to update the priority
+// of an element or to remove it in O(log n) time, and to check its existence in O(1) time.
+// The comparison function is applied on the priority values and defines the heap type.
+type IndexedHeap[K comparable, V any] struct {
+	mu    *sync.RWMutex
+	comp  gogu.CompFn[V]
+	data  []indexedItem[K, V]
+	index map[K]int
+}
+
+// NewIndexed creates a new empty indexed heap ordered by the comparison function.
+func NewIndexed[K comparable, V any](comp gogu.CompFn[V]) *IndexedHeap[K, V] {
+	return &IndexedHeap[K, V]{
+		mu:    new(sync.RWMutex),
+		comp:  comp,
+		data:  make([]indexedItem[K, V], 0),
+		index: make(map[K]int),
+	}
+}
+
+// Size returns the heap size.
+func (h *IndexedHeap[K, V]) Size() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.data)
+}
+
+// IsEmpty checks if the heap is empty or not.
+func (h *IndexedHeap[K, V]) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Clear removes all the elements from the heap.
+func (h *IndexedHeap[K, V]) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.data = h.data[:0]
+	h.index = make(map[K]int)
+}
+
+// Contains checks if an element with the provided key exists in the heap.
+func (h *IndexedHeap[K, V]) Contains(key K) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	_, ok := h.index[key]
+	return ok
+}
+
+// Get returns the priority value of the element with the provided key.
+// The returned boolean is false if the key does not exist.
+func (h *IndexedHeap[K, V]) Get(key K) (V, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if i, ok := h.index[key]; ok {
+		return h.data[i].val, true
+	}
+	var v V
+	return v, false
+}
+
+// Peek returns the key and the value of the first element of the heap without removing it.
+// This can be the minimum or maximum value depending on the heap type.
+// The returned boolean is false if the heap is empty.
+func (h *IndexedHeap[K, V]) Peek() (K, V, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.data) == 0 {
+		var (
+			k K
+			v V
+		)
+		return k, v, false
+	}
+	return h.data[0].key, h.data[0].val, true
+}
+
+// Push inserts a new element into the heap. If the key already exists, its priority value is updated.
+func (h *IndexedHeap[K, V]) Push(key K, val V) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if i, ok := h.index[key]; ok {
+		h.data[i].val = val
+		h.fix(i)
+		return
+	}
+
+	h.data = append(h.data, indexedItem[K, V]{key: key, val: val})
+	h.index[key] = len(h.data) - 1
+	h.moveUp(len(h.data) - 1)
+}
+
+// Pop removes the first element from the heap and returns its key and value.
+// The returned boolean is false if the heap is empty.
+func (h *IndexedHeap[K, V]) Pop() (K, V, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.data) == 0 {
+		var (
+			k K
+			v V
+		)
+		return k, v, false
+	}
+	item := h.remove(0)
+
+	return item.key, item.val, true
+}
+
+// Update changes the priority value of the element with the provided key and restores the heap order.
+// It returns an error in case the key does not exist.
+func (h *IndexedHeap[K, V]) Update(key K, val V) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	i, ok := h.index[key]
+	if !ok {
+		return fmt.Errorf("key not found in the heap: %v", key)
+	}
+	h.data[i].val = val
+	h.fix(i)
+
+	return nil
+}
+
+// Remove removes the element with the provided key from the heap and returns its value.
+// It returns an error in case the key does not exist.
+func (h *IndexedHeap[K, V]) Remove(key K) (V, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	i, ok := h.index[key]
+	if !ok {
+		var v V
+		return v, fmt.Errorf("key not found in the heap: %v", key)
+	}
+
+	return h.remove(i).val, nil
+}
+
+// remove removes the element at index i, replacing it with the last element of the heap.
+func (h *IndexedHeap[K, V]) remove(i int) indexedItem[K, V] {
+	last := len(h.data) - 1
+	item := h.data[i]
+
+	h.swap(i, last)
+	h.data = h.data[:last]
+	delete(h.index, item.key)
+	if i < last {
+		h.fix(i)
+	}
+
+	return item
+}
+
+// fix restores the heap order after the value of the element at index i has been changed.
+func (h *IndexedHeap[K, V]) fix(i int) {
+	if !h.moveDown(i) {
+		h.moveUp(i)
+	}
+}
+
+// moveDown moves the element at index i down to its correct position in the heap.
+// It returns true if the element has been moved.
+func (h *IndexedHeap[K, V]) moveDown(i int) bool {
+	start := i
+	n := len(h.data)
+	for {
+		left, right := 2*i+1, 2*i+2
+		current := i
+
+		if left < n && h.comp(h.data[left].val, h.data[current].val) {
+			current = left
+		}
+		if right < n && h.comp(h.data[right].val, h.data[current].val) {
+			current = right
+		}
+		if current == i {
+			break
+		}
+		h.swap(i, current)
+		i = current
+	}
+
+	return i > start
+}
+
+// moveUp moves the element at index i up to its correct position in the heap.
+func (h *IndexedHeap[K, V]) moveUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.comp(h.data[i].val, h.data[parent].val) {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// swap swaps the elements at index i and j, keeping the index up to date.
+func (h *IndexedHeap[K, V]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+	h.index[h.data[i].key] = i
+	h.index[h.data[j].key] = j
+}
@@ -0,0 +1,136 @@
+package heap
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexedHeap(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewIndexed[string](func(a, b int) bool { return a < b })
+	assert.True(h.IsEmpty())
+	_, _, ok := h.Pop()
+	assert.False(ok)
+	_, _, ok = h.Peek()
+	assert.False(ok)
+
+	h.Push("a", 5)
+	h.Push("b", 3)
+	h.Push("c", 8)
+	h.Push("d", 1)
+	assert.Equal(4, h.Size())
+	assert.True(h.Contains("c"))
+	assert.False(h.Contains("e"))
+
+	key, val, ok := h.Peek()
+	assert.True(ok)
+	assert.Equal("d", key)
+	assert.Equal(1, val)
+
+	// Decrease the priority of an element.
+	assert.NoError(h.Update("c", 0))
+	key, _, _ = h.Peek()
+	assert.Equal("c", key)
+
+	// Increase the priority of an element.
+	assert.NoError(h.Update("c", 10))
+	key, _, _ = h.Peek()
+	assert.Equal("d", key)
+	assert.Error(h.Update("e", 1))
+
+	// Pushing an existing key updates its value.
+	h.Push("a", 2)
+	assert.Equal(4, h.Size())
+	val, ok = h.Get("a")
+	assert.True(ok)
+	assert.Equal(2, val)
+
+	val, err := h.Remove("b")
+	assert.NoError(err)
+	assert.Equal(3, val)
+	assert.False(h.Contains("b"))
+	_, err = h.Remove("b")
+	assert.Error(err)
+
+	var keys []string
+	for !h.IsEmpty() {
+		key, _, _ := h.Pop()
+		keys = append(keys, key)
+	}
+	assert.Equal([]string{"d", "a", "c"}, keys)
+
+	h.Push("a", 1)
+	h.Clear()
+	assert.True(h.IsEmpty())
+	assert.False(h.Contains("a"))
+}
+
+func TestIndexedHeap_Random(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewIndexed[int](func(a, b int) bool { return a > b })
+	values := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		key := rand.Intn(200)
+		switch rand.Intn(3) {
+		case 0, 1:
+			val := rand.Intn(1000)
+			h.Push(key, val)
+			values[key] = val
+		case 2:
+			_, err := h.Remove(key)
+			_, ok := values[key]
+			assert.Equal(ok, err == nil)
+			delete(values, key)
+		}
+	}
+	assert.Equal(len(values), h.Size())
+
+	expected := make([]int, 0, len(values))
+	for _, v := range values {
+		expected = append(expected, v)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(expected)))
+
+	result := make([]int, 0, len(values))
+	for !h.IsEmpty() {
+		_, val, _ := h.Pop()
+		result = append(result, val)
+	}
+	assert.Equal(expected, result)
+}
+
+func Example_indexedHeap() {
+	type edge struct {
+		to     string
+		weight int
+	}
+	graph := map[string][]edge{
+		"a": {{"b", 4}, {"c", 1}},
+		"c": {{"b", 2}, {"d", 5}},
+		"b": {{"d", 1}},
+	}
+
+	// Dijkstra's shortest path algorithm.
+	dist := map[string]int{"a": 0}
+	h := NewIndexed[string](func(a, b int) bool { return a < b })
+	h.Push("a", 0)
+	for !h.IsEmpty() {
+		node, d, _ := h.Pop()
+		for _, e := range graph[node] {
+			if old, ok := dist[e.to]; !ok || d+e.weight < old {
+				dist[e.to] = d + e.weight
+				h.Push(e.to, d+e.weight)
+			}
+		}
+	}
+	fmt.Println(dist)
+
+	// Output:
+	// map[a:0 b:3 c:1 d:4]
+}
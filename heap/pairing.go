@@ -0,0 +1,183 @@
+package heap
+
+import (
+	"sync"
+
+	"github.com/esimov/gogu"
+)
+
+// pairingNode is a node of the pairing heap. The children of a node are stored
+// in a singly linked list, starting with the first child and following the siblings.
+type pairingNode[T any] struct {
+	val     T
+	child   *pairingNode[T]
+	sibling *pairingNode[T]
+}
+
+// PairingHeap is a thread-safe implementation of the pairing heap, a heap ordered multiway tree.
+// Push, Peek and Meld are executed in O(1) time, while Pop runs in O(log n) amortized time.
+// This makes it a good choice in case the heaps need to be melded frequently.
+type PairingHeap[T any] struct {
+	mu   *sync.RWMutex
+	comp gogu.CompFn[T]
+	root *pairingNode[T]
+	size int
+}
+
+// NewPairing creates a new empty pairing heap ordered by the comparison function.
+func NewPairing[T any](comp gogu.CompFn[T]) *PairingHeap[T] {
+	return &PairingHeap[T]{
+		mu:   new(sync.RWMutex),
+		comp: comp,
+	}
+}
+
+// Size returns the heap size.
+func (h *PairingHeap[T]) Size() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.size
+}
+
+// IsEmpty checks if the heap is empty or not.
+func (h *PairingHeap[T]) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Clear removes all the elements from the heap.
+func (h *PairingHeap[T]) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.root, h.size = nil, 0
+}
+
+// Peek returns the first element of the heap.
+// This can be the minimum or maximum value depending on the heap type.
+func (h *PairingHeap[T]) Peek() T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.root == nil {
+		var t T
+		return t
+	}
+	return h.root.val
+}
+
+// Push inserts new elements into the heap.
+func (h *PairingHeap[T]) Push(val ...T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, v := range val {
+		h.root = h.link(h.root, &pairingNode[T]{val: v})
+		h.size++
+	}
+}
+
+// Pop removes the first element from the heap and restructures the remaining subtrees.
+// The removed element is the minimum or maximum depending on the heap type.
+func (h *PairingHeap[T]) Pop() T {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var val T
+	if h.root == nil {
+		return val
+	}
+	val = h.root.val
+	h.root = h.pair(h.root.child)
+	h.size--
+
+	return val
+}
+
+// Merge joins two heaps into a new one preserving the original ones.
+// Since the original heaps are copied, it runs in linear time.
+func (h *PairingHeap[T]) Merge(h2 *PairingHeap[T]) *PairingHeap[T] {
+	newHeap := NewPairing(h.comp)
+	for _, src := range []*PairingHeap[T]{h, h2} {
+		src.mu.RLock()
+		src.each(func(val T) {
+			newHeap.root = newHeap.link(newHeap.root, &pairingNode[T]{val: val})
+			newHeap.size++
+		})
+		src.mu.RUnlock()
+	}
+
+	return newHeap
+}
+
+// Meld merges two heaps into a new one containing all the elements of both and destroying the original ones.
+// It runs in constant time.
+func (h *PairingHeap[T]) Meld(h2 *PairingHeap[T]) *PairingHeap[T] {
+	newHeap := NewPairing(h.comp)
+	for _, src := range []*PairingHeap[T]{h, h2} {
+		src.mu.Lock()
+		newHeap.root = newHeap.link(newHeap.root, src.root)
+		newHeap.size += src.size
+		src.root, src.size = nil, 0
+		src.mu.Unlock()
+	}
+
+	return newHeap
+}
+
+// link melds two heap ordered trees, the root with the lower priority becoming the first child of the other one.
+func (h *PairingHeap[T]) link(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.comp(b.val, a.val) {
+		a, b = b, a
+	}
+	b.sibling = a.child
+	a.child = b
+
+	return a
+}
+
+// pair melds the list of sibling subtrees into a single tree using the two-pass pairing method:
+// the subtrees are linked in pairs from left to right, then the resulting trees are linked from right to left.
+func (h *PairingHeap[T]) pair(first *pairingNode[T]) *pairingNode[T] {
+	var pairs []*pairingNode[T]
+	for first != nil {
+		a, b := first, first.sibling
+		if b == nil {
+			a.sibling = nil
+			pairs = append(pairs, a)
+			break
+		}
+		first = b.sibling
+		a.sibling, b.sibling = nil, nil
+		pairs = append(pairs, h.link(a, b))
+	}
+
+	var root *pairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.link(root, pairs[i])
+	}
+
+	return root
+}
+
+// each calls fn for every element of the heap, in no particular order.
+func (h *PairingHeap[T]) each(fn func(T)) {
+	if h.root == nil {
+		return
+	}
+	stack := []*pairingNode[T]{h.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		fn(n.val)
+		for c := n.child; c != nil; c = c.sibling {
+			stack = append(stack, c)
+		}
+	}
+}
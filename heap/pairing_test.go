@@ -0,0 +1,84 @@
+package heap
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairingHeap(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewPairing(func(a, b int) bool { return a < b })
+	assert.True(h.IsEmpty())
+	assert.Equal(0, h.Pop())
+	assert.Equal(0, h.Peek())
+
+	input := rand.Perm(1000)
+	h.Push(input...)
+	assert.Equal(1000, h.Size())
+	assert.Equal(0, h.Peek())
+
+	for i := 0; i < 500; i++ {
+		assert.Equal(i, h.Pop())
+	}
+	h.Push(-1, 2000)
+	assert.Equal(-1, h.Pop())
+	for i := 500; i < 1000; i++ {
+		assert.Equal(i, h.Pop())
+	}
+	assert.Equal(2000, h.Pop())
+	assert.True(h.IsEmpty())
+
+	h.Push(1, 2, 3)
+	h.Clear()
+	assert.Equal(0, h.Size())
+}
+
+func TestPairingHeap_Merge(t *testing.T) {
+	assert := assert.New(t)
+
+	h1 := NewPairing(func(a, b int) bool { return a < b })
+	h1.Push(6, 2, 8)
+	h2 := NewPairing(func(a, b int) bool { return a < b })
+	h2.Push(5, 1, 7)
+
+	h := h1.Merge(h2)
+	assert.Equal(3, h1.Size())
+	assert.Equal(3, h2.Size())
+	assert.Equal(6, h.Size())
+	assert.Equal(1, h.Peek())
+
+	// The merged heap does not share the nodes with the original heaps.
+	h.Pop()
+	assert.Equal(2, h1.Peek())
+	assert.Equal(1, h2.Peek())
+
+	h = h1.Meld(h2)
+	assert.True(h1.IsEmpty())
+	assert.True(h2.IsEmpty())
+	assert.Equal(6, h.Size())
+
+	var result []int
+	for !h.IsEmpty() {
+		result = append(result, h.Pop())
+	}
+	assert.Equal([]int{1, 2, 5, 6, 7, 8}, result)
+}
+
+func Example_pairingHeap() {
+	h1 := NewPairing(func(a, b string) bool { return a < b })
+	h1.Push("c", "a")
+	h2 := NewPairing(func(a, b string) bool { return a < b })
+	h2.Push("d", "b")
+
+	h := h1.Meld(h2)
+	for !h.IsEmpty() {
+		fmt.Print(h.Pop(), " ")
+	}
+
+	// Output:
+	// a b c d
+}
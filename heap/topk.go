@@ -0,0 +1,58 @@
+package heap
+
+import (
+	"github.com/esimov/gogu"
+)
+
+// Selector selects the first k elements of a stream of values, in the order defined by the comparison function,
+// using O(k) memory. With a comparison function like a > b it selects the k largest values,
+// while with a comparison function like a < b it selects the k smallest ones.
+type Selector[T comparable] struct {
+	heap *Heap[T]
+}
+
+// NewSelector creates a new Selector retaining the first k elements in the order defined by the comparison function.
+func NewSelector[T comparable](k int, comp gogu.CompFn[T]) *Selector[T] {
+	// The worst retained element is kept at the top of the heap, so that it can be discarded first.
+	return &Selector[T]{
+		heap: NewBounded(k, func(a, b T) bool { return comp(b, a) }),
+	}
+}
+
+// Add pushes new values into the Selector, discarding the values which are not among the first k elements.
+func (s *Selector[T]) Add(val ...T) {
+	if s.heap.Capacity() <= 0 {
+		return
+	}
+	s.heap.Push(val...)
+}
+
+// Len returns the number of the retained elements, which is at most k.
+func (s *Selector[T]) Len() int {
+	return s.heap.Size()
+}
+
+// Result returns the retained elements sorted by the comparison function.
+// The Selector can be used further to process the stream.
+func (s *Selector[T]) Result() []T {
+	s.heap.mu.RLock()
+	data := append([]T(nil), s.heap.data...)
+	s.heap.mu.RUnlock()
+
+	return Sort(data, s.heap.comp)
+}
+
+// Reset discards all the retained elements.
+func (s *Selector[T]) Reset() {
+	s.heap.Clear()
+}
+
+// TopK returns the first k elements of the slice in the order defined by the comparison function.
+// With a comparison function like a > b it returns the k largest values in descending order.
+// It runs in O(n log k) time.
+func TopK[T comparable](data []T, k int, comp gogu.CompFn[T]) []T {
+	s := NewSelector(k, comp)
+	s.Add(data...)
+
+	return s.Result()
+}
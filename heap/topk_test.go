@@ -0,0 +1,86 @@
+package heap
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeap_Bounded(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewBounded(3, func(a, b int) bool { return a < b })
+	assert.Equal(3, h.Capacity())
+
+	h.Push(5, 1, 8, 3, 9, 2, 7)
+	assert.Equal(3, h.Size())
+	assert.Equal(7, h.Peek())
+
+	var result []int
+	for !h.IsEmpty() {
+		result = append(result, h.Pop())
+	}
+	assert.Equal([]int{7, 8, 9}, result)
+
+	h2 := NewBounded(2, func(a, b int) bool { return a < b })
+	h2.Push(1, 2)
+	h = h2.Merge(NewHeap(func(a, b int) bool { return a < b }))
+	assert.Equal(2, h.Capacity())
+	h.Push(0, 3)
+	assert.Equal(2, h.Size())
+	assert.Equal(2, h.Peek())
+}
+
+func TestTopK(t *testing.T) {
+	assert := assert.New(t)
+
+	data := rand.Perm(1000)
+	assert.Equal([]int{999, 998, 997, 996, 995}, TopK(data, 5, func(a, b int) bool { return a > b }))
+	assert.Equal([]int{0, 1, 2}, TopK(data, 3, func(a, b int) bool { return a < b }))
+	assert.Len(TopK(data, 2000, func(a, b int) bool { return a < b }), 1000)
+	assert.Empty(TopK(data, 0, func(a, b int) bool { return a < b }))
+	assert.Empty(TopK([]int{}, 3, func(a, b int) bool { return a < b }))
+}
+
+func TestSelector(t *testing.T) {
+	assert := assert.New(t)
+
+	type score struct {
+		name   string
+		points int
+	}
+	s := NewSelector(3, func(a, b score) bool { return a.points > b.points })
+
+	var all []score
+	for i := 0; i < 100; i++ {
+		sc := score{name: fmt.Sprint("player", i), points: rand.Intn(1000)}
+		all = append(all, sc)
+		s.Add(sc)
+		assert.LessOrEqual(s.Len(), 3)
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].points > all[j].points })
+
+	result := s.Result()
+	assert.Len(result, 3)
+	for i := range result {
+		assert.Equal(all[i].points, result[i].points)
+	}
+	// Result does not consume the retained elements.
+	assert.Equal(3, s.Len())
+
+	s.Reset()
+	assert.Equal(0, s.Len())
+}
+
+func Example_topK() {
+	data := []int{5, 1, 8, 3, 9, 2, 7}
+	fmt.Println(TopK(data, 3, func(a, b int) bool { return a > b }))
+	fmt.Println(TopK(data, 3, func(a, b int) bool { return a < b }))
+
+	// Output:
+	// [9 8 7]
+	// [1 2 3]
+}
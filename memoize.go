@@ -1,26 +1,69 @@
 package gogu
 
 import (
+	"sync"
 	"time"
 
 	"github.com/esimov/gogu/cache"
-	"golang.org/x/sync/singleflight"
+	"go.uber.org/multierr"
 )
 
-// Memoizer is a two component struct type used to memoize the results of a function execution.
-// It holds an exported Cache storage and a singleflight group which is used
+// Memoizer is a struct type used to memoize the results of a function execution.
+// It holds an exported Cache storage and a flight group which is used
 // to guarantee that only one function execution is in flight for a given key.
-type Memoizer[T ~string, V any] struct {
+type Memoizer[T comparable, V any] struct {
 	Cache *cache.Cache[T, V]
-	group *singleflight.Group
+
+	group      *cache.Group[T, *cache.Item[V]]
+	stale      bool
+	errs       *cache.Cache[T, error]
+	refreshing *sync.Map
+}
+
+// MemoizerOption is used to customize the Memoizer behavior.
+type MemoizerOption func(*memoizerOptions)
+
+type memoizerOptions struct {
+	stale    bool
+	errorTTL time.Duration
+}
+
+// WithStaleWhileRevalidate makes the Memoizer serve the expired results instantly, while the function
+// is executed in the background to refresh them. Only one refresh is in flight for a given key at a time.
+// The expired results are served until they are removed from the cache by the cleanup process,
+// which means that the cleanup interval defines for how long a stale result can be served.
+func WithStaleWhileRevalidate() MemoizerOption {
+	return func(o *memoizerOptions) {
+		o.stale = true
+	}
+}
+
+// WithErrorTTL caches the errors returned by the memoized function for the provided duration,
+// during which the function is not executed again for the same key and the cached error is returned instead.
+// In stale-while-revalidate mode the stale result is still served, but its refresh is postponed.
+func WithErrorTTL(d time.Duration) MemoizerOption {
+	return func(o *memoizerOptions) {
+		o.errorTTL = d
+	}
 }
 
 // NewMemoizer instantiates a new Memoizer.
-func NewMemoizer[T ~string, V any](expiration, cleanup time.Duration) *Memoizer[T, V] {
-	return &Memoizer[T, V]{
-		Cache: cache.New[T, V](expiration, cleanup),
-		group: &singleflight.Group{},
+func NewMemoizer[T comparable, V any](expiration, cleanup time.Duration, opts ...MemoizerOption) *Memoizer[T, V] {
+	var o memoizerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	m := &Memoizer[T, V]{
+		Cache:      cache.New[T, V](expiration, cleanup),
+		group:      &cache.Group[T, *cache.Item[V]]{},
+		stale:      o.stale,
+		refreshing: &sync.Map{},
 	}
+	if o.errorTTL > 0 {
+		m.errs = cache.New[T, error](o.errorTTL, cleanup)
+	}
+	return m
 }
 
 // Memoize returns the item under a specific key instantly in case the key exists,
@@ -29,19 +72,129 @@ func NewMemoizer[T ~string, V any](expiration, cleanup time.Duration) *Memoizer[
 //
 // This method is useful for caching the result of a time-consuming operation when is more important
 // to return a slightly outdated result, than to wait for an operation to complete before serving it.
+// If the Memoizer has been created with the WithStaleWhileRevalidate option, the expired items are returned
+// instantly, while their refresh is running in the background.
 func (m Memoizer[T, V]) Memoize(key T, fn func() (*cache.Item[V], error)) (*cache.Item[V], error) {
-	item, _ := m.Cache.Get(key)
-	if item != nil {
+	if m.stale {
+		if item, expired, err := m.Cache.GetStale(key); err == nil && expired {
+			// A recently failed refresh is not retried until its cached error expires.
+			if m.failure(key) == nil {
+				m.revalidate(key, fn)
+			}
+			return item, nil
+		}
+	}
+
+	if err := m.failure(key); err != nil {
+		return nil, err
+	}
+
+	if item, err := m.Cache.Get(key); err == nil {
 		return item, nil
 	}
 
-	data, err, _ := m.group.Do(string(key), func() (any, error) {
+	return m.group.Do(key, func() (*cache.Item[V], error) {
 		item, err := fn()
-		if err == nil {
-			m.Cache.SetDefault(key, item.Val())
+		if err != nil {
+			m.fail(key, err)
+			return item, err
 		}
-		return item, err
+		// The result of the function is returned even if the cache rejects its value.
+		m.Cache.Update(key, item.Val(), cache.DefaultExpiration)
+		return item, nil
 	})
+}
+
+// Close stops the cleanup goroutines of the Memoizer, closing both the results cache
+// and the errors cache created by the WithErrorTTL option.
+// Closing an already closed Memoizer returns cache.ErrorClosed.
+func (m Memoizer[T, V]) Close() error {
+	err := m.Cache.Close()
+	if m.errs != nil {
+		err = multierr.Append(err, m.errs.Close())
+	}
+	return err
+}
+
+// revalidate refreshes the item under the key in the background,
+// unless another refresh of the same key is already in flight.
+func (m Memoizer[T, V]) revalidate(key T, fn func() (*cache.Item[V], error)) {
+	if _, running := m.refreshing.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+
+	go func() {
+		defer m.refreshing.Delete(key)
+
+		item, err := fn()
+		if err != nil {
+			m.fail(key, err)
+			return
+		}
+		m.Cache.Update(key, item.Val(), cache.DefaultExpiration)
+	}()
+}
+
+// failure returns the cached error of the memoized function under the key, if there is any.
+func (m Memoizer[T, V]) failure(key T) error {
+	if m.errs == nil {
+		return nil
+	}
+	if item, err := m.errs.Get(key); err == nil {
+		return item.Val()
+	}
+	return nil
+}
+
+// fail caches the error returned by the memoized function, if error caching is enabled.
+func (m Memoizer[T, V]) fail(key T, err error) {
+	if m.errs != nil {
+		m.errs.Update(key, err, cache.DefaultExpiration)
+	}
+}
+
+// memoized wraps the results of the memoized functions, so that the values rejected
+// by the cache (like the empty strings) are memoized too.
+type memoized[R any] struct {
+	val R
+}
+
+// memoKey is the cache key of the functions memoized by Memoize2.
+type memoKey[A, B comparable] struct {
+	a A
+	b B
+}
+
+// Memoize1 returns a memoized version of the single argument function fn. The results are cached
+// for the ttl duration, which can be cache.NoExpiration, under the function argument. The expired results
+// are removed from the cache at the cleanup interval, a cleanup interval less than or equal to zero
+// disabling the cleanup process. The cache can be customized with the options provided by the cache package,
+// like cache.WithCapacity. Concurrent calls with the same argument are deduplicated and the failed calls
+// are not cached. The returned close function stops the cleanup goroutine and releases the cache.
+func Memoize1[A comparable, R any](fn func(A) (R, error), ttl, cleanup time.Duration, opts ...cache.Option) (func(A) (R, error), func() error) {
+	c := cache.New[A, memoized[R]](ttl, cleanup, opts...)
+
+	return func(a A) (R, error) {
+		item, err := c.GetOrLoad(a, func() (memoized[R], error) {
+			res, err := fn(a)
+			return memoized[R]{res}, err
+		})
+		if err != nil {
+			var res R
+			return res, err
+		}
+		return item.Val().val, nil
+	}, c.Close
+}
+
+// Memoize2 returns a memoized version of the two arguments function fn.
+// It works the same way as Memoize1, the results being cached under the pair of arguments.
+func Memoize2[A, B comparable, R any](fn func(A, B) (R, error), ttl, cleanup time.Duration, opts ...cache.Option) (func(A, B) (R, error), func() error) {
+	memo, closer := Memoize1(func(k memoKey[A, B]) (R, error) {
+		return fn(k.a, k.b)
+	}, ttl, cleanup, opts...)
 
-	return data.(*cache.Item[V]), err
+	return func(a A, b B) (R, error) {
+		return memo(memoKey[A, B]{a, b})
+	}, closer
 }
@@ -2,6 +2,8 @@ package gogu
 
 import (
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -102,3 +104,225 @@ func Example_memoize() {
 	// one
 	// one
 }
+
+func TestMemoize_ComparableKey(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	m := NewMemoizer[int, string](time.Second, time.Minute)
+	fn := func() (*cache.Item[string], error) {
+		calls++
+		return nil, fmt.Errorf("memoize error")
+	}
+	_, err := m.Memoize(1, fn)
+	assert.Error(err)
+	_, err = m.Memoize(1, fn)
+	assert.Error(err)
+	assert.Equal(2, calls)
+	assert.Equal(0, m.Cache.Count())
+}
+
+func TestMemoize_EmptyValue(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMemoizer[string, string](time.Minute, time.Minute)
+
+	item, err := m.Memoize("nil", func() (*cache.Item[string], error) {
+		return nil, nil
+	})
+	assert.NoError(err)
+	assert.Nil(item)
+
+	// The item returned by the function is returned as is, not the one stored in the cache.
+	other, _, _ := cache.New[string, string](time.Minute, 0).GetOrSet("foo", "bar", cache.DefaultExpiration)
+	item, err = m.Memoize("foo", func() (*cache.Item[string], error) {
+		return other, nil
+	})
+	assert.NoError(err)
+	assert.Same(other, item)
+	cached, err := m.Cache.Get("foo")
+	assert.NoError(err)
+	assert.Equal("bar", cached.Val())
+}
+
+func TestMemoize_StaleWhileRevalidate(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	src := cache.New[string, int](cache.NoExpiration, 0)
+	// The results expire instantly, so that every call after the first one is served from stale results.
+	m := NewMemoizer[string, int](time.Nanosecond, time.Minute, WithStaleWhileRevalidate())
+	fn := func() (*cache.Item[int], error) {
+		n := calls.Add(1)
+		// The refreshes are blocked until the test releases them.
+		if n > 1 {
+			started <- struct{}{}
+			<-release
+		}
+		src.Update("val", int(n), cache.DefaultExpiration)
+		return src.Get("val")
+	}
+
+	item, err := m.Memoize("key", fn)
+	assert.NoError(err)
+	assert.Equal(1, item.Val())
+	assert.Eventually(func() bool {
+		_, expired, _ := m.Cache.GetStale("key")
+		return expired
+	}, time.Second, time.Millisecond)
+
+	// The expired item is served without waiting for the refresh, which is running in the background.
+	item, err = m.Memoize("key", fn)
+	assert.NoError(err)
+	assert.Equal(1, item.Val())
+	<-started
+
+	// Only one refresh is in flight at a time.
+	for i := 0; i < 5; i++ {
+		item, err = m.Memoize("key", fn)
+		assert.NoError(err)
+		assert.Equal(1, item.Val())
+	}
+	assert.Equal(int32(2), calls.Load())
+
+	close(release)
+	assert.Eventually(func() bool {
+		item, _, _ := m.Cache.GetStale("key")
+		return item.Val() == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(int32(2), calls.Load())
+}
+
+func TestMemoize_ErrorTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	src := cache.New[string, int](cache.NoExpiration, 0)
+	m := NewMemoizer[string, int](time.Second, time.Minute, WithErrorTTL(20*time.Millisecond))
+	fn := func() (*cache.Item[int], error) {
+		calls++
+		if calls == 1 {
+			return nil, fmt.Errorf("memoize error")
+		}
+		src.Update("val", calls, cache.DefaultExpiration)
+		return src.Get("val")
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := m.Memoize("key", fn)
+		assert.EqualError(err, "memoize error")
+	}
+	assert.Equal(1, calls)
+
+	<-time.After(30 * time.Millisecond)
+	item, err := m.Memoize("key", fn)
+	assert.NoError(err)
+	assert.Equal(2, item.Val())
+	assert.Equal(2, calls)
+
+	assert.NoError(m.Close())
+	assert.ErrorIs(m.Close(), cache.ErrorClosed)
+	_, err = m.errs.Get("key")
+	assert.ErrorIs(err, cache.ErrorClosed)
+}
+
+func TestMemoize1(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	square, closer := Memoize1(func(n int) (int, error) {
+		calls++
+		if n < 0 {
+			return 0, fmt.Errorf("negative number")
+		}
+		return n * n, nil
+	}, 20*time.Millisecond, time.Minute, cache.WithCapacity(2))
+
+	for i := 0; i < 3; i++ {
+		res, err := square(3)
+		assert.NoError(err)
+		assert.Equal(9, res)
+	}
+	assert.Equal(1, calls)
+
+	// The failed calls are not cached.
+	_, err := square(-1)
+	assert.Error(err)
+	_, err = square(-1)
+	assert.Error(err)
+	assert.Equal(3, calls)
+
+	// The least recently used result is evicted once the capacity is reached.
+	square(4)
+	square(5)
+	square(3)
+	assert.Equal(6, calls)
+
+	// The results expire after the ttl.
+	<-time.After(30 * time.Millisecond)
+	res, err := square(5)
+	assert.NoError(err)
+	assert.Equal(25, res)
+	assert.Equal(7, calls)
+
+	assert.NoError(closer())
+	_, err = square(5)
+	assert.ErrorIs(err, cache.ErrorClosed)
+
+	// The empty strings are memoized too.
+	calls = 0
+	empty, _ := Memoize1(func(s string) (string, error) {
+		calls++
+		return "", nil
+	}, cache.NoExpiration, 0)
+	for i := 0; i < 2; i++ {
+		res, err := empty("foo")
+		assert.NoError(err)
+		assert.Empty(res)
+	}
+	assert.Equal(1, calls)
+}
+
+func TestMemoize2(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	repeat, closer := Memoize2(func(s string, n int) (string, error) {
+		calls++
+		return strings.Repeat(s, n), nil
+	}, time.Minute, time.Minute)
+	defer closer()
+
+	res, err := repeat("a", 3)
+	assert.NoError(err)
+	assert.Equal("aaa", res)
+	res, err = repeat("a", 3)
+	assert.NoError(err)
+	assert.Equal("aaa", res)
+	assert.Equal(1, calls)
+
+	res, err = repeat("b", 3)
+	assert.NoError(err)
+	assert.Equal("bbb", res)
+	res, err = repeat("a", 2)
+	assert.NoError(err)
+	assert.Equal("aa", res)
+	assert.Equal(3, calls)
+}
+
+func Example_memoize2() {
+	sum, closer := Memoize2(func(a, b int) (int, error) {
+		fmt.Println("computing")
+		return a + b, nil
+	}, time.Minute, time.Minute)
+	defer closer()
+
+	fmt.Println(sum(1, 2))
+	fmt.Println(sum(1, 2))
+
+	// Output:
+	// computing
+	// 3 <nil>
+	// 3 <nil>
+}
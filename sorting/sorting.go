@@ -0,0 +1,237 @@
+// Package sorting provides generic sorting algorithms complementing the heap sort of the heap package:
+// a stable merge sort, an introsort and a parallel merge sort for large slices.
+// All the algorithms sort the slices in place, in the order defined by the comparison function,
+// which should report whether its first argument must be placed before the second one.
+package sorting
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/esimov/gogu"
+	"golang.org/x/exp/constraints"
+)
+
+const (
+	// insertionThreshold is the slice length under which the insertion sort is used.
+	insertionThreshold = 12
+	// parallelThreshold is the slice length under which the parallel merge sort sorts sequentially.
+	parallelThreshold = 2048
+)
+
+// MergeSort sorts the slice using the merge sort algorithm and returns it.
+// The sort is stable, which means that the equal elements keep their original order.
+// It runs in O(n log n) time and uses O(n) additional memory.
+func MergeSort[T any](data []T, comp gogu.CompFn[T]) []T {
+	buf := make([]T, len(data))
+	mergeSort(data, buf, comp)
+
+	return data
+}
+
+// ParallelMergeSort sorts the slice using a merge sort which distributes the work over the available CPUs
+// and returns it. Like MergeSort, the sort is stable. It is faster than MergeSort only on large slices,
+// the small slices being sorted sequentially.
+func ParallelMergeSort[T any](data []T, comp gogu.CompFn[T]) []T {
+	buf := make([]T, len(data))
+
+	// Each level of recursion doubles the number of goroutines.
+	depth := 0
+	for n := runtime.GOMAXPROCS(0); n > 1; n >>= 1 {
+		depth++
+	}
+	parallelMergeSort(data, buf, comp, depth+1)
+
+	return data
+}
+
+// IntroSort sorts the slice using the introsort algorithm and returns it. Introsort begins with quicksort,
+// switching to heap sort when the recursion depth becomes too high and to insertion sort for small partitions.
+// This guarantees O(n log n) time in the worst case, without additional memory. The sort is not stable.
+func IntroSort[T any](data []T, comp gogu.CompFn[T]) []T {
+	depth := 0
+	for n := len(data); n > 0; n >>= 1 {
+		depth++
+	}
+	introSort(data, comp, 2*depth)
+
+	return data
+}
+
+// SortBy sorts the slice in ascending order of the keys returned by the key function and returns it.
+// The key function is invoked only once per element. The sort is stable.
+func SortBy[T any, K constraints.Ordered](data []T, keyFn func(T) K) []T {
+	return SortByFunc(data, keyFn, func(a, b K) bool { return a < b })
+}
+
+// SortByFunc sorts the slice in the order defined by the comparison function applied
+// on the keys returned by the key function and returns it.
+// The key function is invoked only once per element. The sort is stable.
+func SortByFunc[T, K any](data []T, keyFn func(T) K, comp gogu.CompFn[K]) []T {
+	type keyed struct {
+		key K
+		val T
+	}
+
+	items := make([]keyed, len(data))
+	for i, v := range data {
+		items[i] = keyed{keyFn(v), v}
+	}
+	MergeSort(items, func(a, b keyed) bool { return comp(a.key, b.key) })
+	for i := range items {
+		data[i] = items[i].val
+	}
+
+	return data
+}
+
+// mergeSort sorts the data using buf as temporary storage. Both slices have the same length.
+func mergeSort[T any](data, buf []T, comp gogu.CompFn[T]) {
+	if len(data) <= insertionThreshold {
+		insertionSort(data, comp)
+		return
+	}
+	mid := len(data) / 2
+	mergeSort(data[:mid], buf[:mid], comp)
+	mergeSort(data[mid:], buf[mid:], comp)
+	merge(data, buf, mid, comp)
+}
+
+// parallelMergeSort sorts the two halves of the data concurrently, until the depth reaches zero
+// or the slice becomes too small.
+func parallelMergeSort[T any](data, buf []T, comp gogu.CompFn[T], depth int) {
+	if depth <= 0 || len(data) <= parallelThreshold {
+		mergeSort(data, buf, comp)
+		return
+	}
+
+	mid := len(data) / 2
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		parallelMergeSort(data[:mid], buf[:mid], comp, depth-1)
+	}()
+	parallelMergeSort(data[mid:], buf[mid:], comp, depth-1)
+	wg.Wait()
+
+	merge(data, buf, mid, comp)
+}
+
+// merge merges the sorted data[:mid] and data[mid:] subslices. On equal elements
+// the one from the left subslice comes first, which makes the merge stable.
+func merge[T any](data, buf []T, mid int, comp gogu.CompFn[T]) {
+	// The subslices are already in order.
+	if !comp(data[mid], data[mid-1]) {
+		return
+	}
+	copy(buf, data)
+
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(data) {
+		if comp(buf[j], buf[i]) {
+			data[k] = buf[j]
+			j++
+		} else {
+			data[k] = buf[i]
+			i++
+		}
+		k++
+	}
+	// The remaining elements of the right subslice are already in place.
+	copy(data[k:], buf[i:mid])
+}
+
+// insertionSort sorts small slices. It's stable.
+func insertionSort[T any](data []T, comp gogu.CompFn[T]) {
+	for i := 1; i < len(data); i++ {
+		for j := i; j > 0 && comp(data[j], data[j-1]); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// introSort sorts the data using quicksort, falling back to heap sort once the depth limit is reached.
+func introSort[T any](data []T, comp gogu.CompFn[T], depth int) {
+	for len(data) > insertionThreshold {
+		if depth == 0 {
+			heapSort(data, comp)
+			return
+		}
+		depth--
+
+		p := partition(data, comp)
+		// Recurse into the smaller partition to bound the stack size.
+		if p < len(data)-p {
+			introSort(data[:p], comp, depth)
+			data = data[p+1:]
+		} else {
+			introSort(data[p+1:], comp, depth)
+			data = data[:p]
+		}
+	}
+	insertionSort(data, comp)
+}
+
+// partition partitions the data around the median of the first, middle and last elements
+// and returns the final position of the pivot.
+func partition[T any](data []T, comp gogu.CompFn[T]) int {
+	lo, mid, hi := 0, len(data)/2, len(data)-1
+	if comp(data[mid], data[lo]) {
+		data[mid], data[lo] = data[lo], data[mid]
+	}
+	if comp(data[hi], data[lo]) {
+		data[hi], data[lo] = data[lo], data[hi]
+	}
+	if comp(data[hi], data[mid]) {
+		data[hi], data[mid] = data[mid], data[hi]
+	}
+	// Move the pivot next to the end, the last element being already greater or equal.
+	data[mid], data[hi-1] = data[hi-1], data[mid]
+	pivot := data[hi-1]
+
+	i, j := lo, hi-1
+	for {
+		for i++; comp(data[i], pivot); i++ {
+		}
+		for j--; comp(pivot, data[j]); j-- {
+		}
+		if i >= j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+	}
+	data[i], data[hi-1] = data[hi-1], data[i]
+
+	return i
+}
+
+// heapSort sorts the data using a max heap built with the comparison function.
+func heapSort[T any](data []T, comp gogu.CompFn[T]) {
+	for i := len(data)/2 - 1; i >= 0; i-- {
+		siftDown(data, i, len(data), comp)
+	}
+	for i := len(data) - 1; i > 0; i-- {
+		data[0], data[i] = data[i], data[0]
+		siftDown(data, 0, i, comp)
+	}
+}
+
+// siftDown moves the element at index i down the max heap stored in data[:n].
+func siftDown[T any](data []T, i, n int, comp gogu.CompFn[T]) {
+	for {
+		current := i
+		left, right := 2*i+1, 2*i+2
+		if left < n && comp(data[current], data[left]) {
+			current = left
+		}
+		if right < n && comp(data[current], data[right]) {
+			current = right
+		}
+		if current == i {
+			return
+		}
+		data[i], data[current] = data[current], data[i]
+		i = current
+	}
+}
@@ -0,0 +1,162 @@
+package sorting
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/esimov/gogu"
+	"github.com/esimov/gogu/heap"
+	"github.com/stretchr/testify/assert"
+)
+
+type record struct {
+	key, pos int
+}
+
+var sorters = map[string]func([]int, gogu.CompFn[int]) []int{
+	"MergeSort":         MergeSort[int],
+	"ParallelMergeSort": ParallelMergeSort[int],
+	"IntroSort":         IntroSort[int],
+}
+
+func inputs(n int) map[string][]int {
+	random := rand.Perm(n)
+	sorted := make([]int, n)
+	reversed := make([]int, n)
+	equal := make([]int, n)
+	few := make([]int, n)
+	for i := 0; i < n; i++ {
+		sorted[i] = i
+		reversed[i] = n - i
+		few[i] = rand.Intn(5)
+	}
+	return map[string][]int{
+		"random":   random,
+		"sorted":   sorted,
+		"reversed": reversed,
+		"equal":    equal,
+		"few":      few,
+	}
+}
+
+func TestSort(t *testing.T) {
+	assert := assert.New(t)
+
+	for name, sortFn := range sorters {
+		for _, n := range []int{0, 1, 2, 5, 13, 100, 5000} {
+			for kind, input := range inputs(n) {
+				expected := append([]int(nil), input...)
+				sort.Ints(expected)
+
+				data := append([]int(nil), input...)
+				res := sortFn(data, func(a, b int) bool { return a < b })
+				assert.Equal(expected, res, "%s %s %d", name, kind, n)
+				assert.Equal(expected, data, "%s %s %d", name, kind, n)
+
+				sort.Sort(sort.Reverse(sort.IntSlice(expected)))
+				res = sortFn(data, func(a, b int) bool { return a > b })
+				assert.Equal(expected, res, "%s %s %d", name, kind, n)
+			}
+		}
+	}
+}
+
+func TestSort_Stable(t *testing.T) {
+	assert := assert.New(t)
+
+	stable := map[string]func([]record, gogu.CompFn[record]) []record{
+		"MergeSort":         MergeSort[record],
+		"ParallelMergeSort": ParallelMergeSort[record],
+	}
+	for name, sortFn := range stable {
+		data := make([]record, 10000)
+		for i := range data {
+			data[i] = record{key: rand.Intn(50), pos: i}
+		}
+		sortFn(data, func(a, b record) bool { return a.key < b.key })
+		for i := 1; i < len(data); i++ {
+			assert.LessOrEqual(data[i-1].key, data[i].key, name)
+			if data[i-1].key == data[i].key {
+				assert.Less(data[i-1].pos, data[i].pos, name)
+			}
+		}
+	}
+}
+
+func TestIntroSort_HeapSortFallback(t *testing.T) {
+	assert := assert.New(t)
+
+	data := rand.Perm(1000)
+	introSort(data, func(a, b int) bool { return a < b }, 0)
+	assert.True(sort.IntsAreSorted(data))
+}
+
+func TestSortBy(t *testing.T) {
+	assert := assert.New(t)
+
+	type person struct {
+		name string
+		age  int
+	}
+	persons := []person{
+		{"John", 23}, {"Eveline", 32}, {"Rick", 23}, {"Kim", 18}, {"Tommy", 32},
+	}
+
+	calls := 0
+	SortBy(persons, func(p person) int {
+		calls++
+		return p.age
+	})
+	assert.Equal(len(persons), calls)
+	assert.Equal([]person{
+		{"Kim", 18}, {"John", 23}, {"Rick", 23}, {"Eveline", 32}, {"Tommy", 32},
+	}, persons)
+
+	SortByFunc(persons, func(p person) string { return p.name }, func(a, b string) bool { return a > b })
+	assert.Equal([]string{"Tommy", "Rick", "Kim", "John", "Eveline"}, []string{
+		persons[0].name, persons[1].name, persons[2].name, persons[3].name, persons[4].name,
+	})
+}
+
+func Example() {
+	data := []int{5, 2, 8, 1, 9, 3}
+	fmt.Println(MergeSort(data, func(a, b int) bool { return a < b }))
+	fmt.Println(IntroSort(data, func(a, b int) bool { return a > b }))
+
+	words := []string{"banana", "kiwi", "apple", "fig"}
+	fmt.Println(SortBy(words, func(s string) int { return len(s) }))
+
+	// Output:
+	// [1 2 3 5 8 9]
+	// [9 8 5 3 2 1]
+	// [fig kiwi apple banana]
+}
+
+func BenchmarkSort(b *testing.B) {
+	less := func(a, b int) bool { return a < b }
+
+	benchmarks := map[string]func([]int){
+		"HeapSort": func(data []int) {
+			// heap.Sort sorts in ascending order when it's called with a max heap comparator.
+			heap.Sort(data, func(a, b int) bool { return a > b })
+		},
+		"MergeSort":         func(data []int) { MergeSort(data, less) },
+		"ParallelMergeSort": func(data []int) { ParallelMergeSort(data, less) },
+		"IntroSort":         func(data []int) { IntroSort(data, less) },
+	}
+	for _, n := range []int{1000, 10000} {
+		input := rand.Perm(n)
+		for _, name := range []string{"HeapSort", "MergeSort", "ParallelMergeSort", "IntroSort"} {
+			sortFn := benchmarks[name]
+			b.Run(fmt.Sprintf("%s-%d", name, n), func(b *testing.B) {
+				data := make([]int, n)
+				for i := 0; i < b.N; i++ {
+					copy(data, input)
+					sortFn(data)
+				}
+			})
+		}
+	}
+}